@@ -0,0 +1,86 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package acc
+
+import (
+	"strings"
+	"sync"
+
+	"github.com/elastic/cloud-sdk-go/pkg/api"
+	"github.com/elastic/cloud-sdk-go/pkg/client/extensions"
+	"github.com/elastic/cloud-sdk-go/pkg/multierror"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+func init() {
+	resource.AddTestSweepers("ec_deployment_extension", &resource.Sweeper{
+		Name: "ec_deployment_extension",
+		F:    testSweepDeploymentExtension,
+		// Extensions which are in use by a deployment can't be deleted.
+		Dependencies: []string{"ec_deployment"},
+	})
+}
+
+func testSweepDeploymentExtension(_ string) error {
+	client, err := NewAPI()
+	if err != nil {
+		return err
+	}
+
+	res, err := client.V1API.Extensions.ListExtensions(
+		extensions.NewListExtensionsParams(),
+		client.AuthWriter,
+	)
+	if err != nil {
+		return api.UnwrapError(err)
+	}
+
+	var sweepExtensions []string
+	for _, e := range res.Payload.Extensions {
+		if strings.HasPrefix(*e.Name, prefix) {
+			sweepExtensions = append(sweepExtensions, *e.ID)
+		}
+	}
+
+	var merr = multierror.NewPrefixed("failed sweeping extensions")
+	var mu sync.Mutex
+	var wg sync.WaitGroup
+	for _, ext := range sweepExtensions {
+		wg.Add(1)
+		go func(id string) {
+			defer wg.Done()
+			if err := deleteExtension(client, id); err != nil {
+				mu.Lock()
+				merr = merr.Append(err)
+				mu.Unlock()
+			}
+		}(ext)
+	}
+	wg.Wait()
+
+	return merr.ErrorOrNil()
+}
+
+func deleteExtension(c *api.API, id string) error {
+	_, err := c.V1API.Extensions.DeleteExtension(
+		extensions.NewDeleteExtensionParams().
+			WithExtensionID(id),
+		c.AuthWriter,
+	)
+	return api.UnwrapError(err)
+}
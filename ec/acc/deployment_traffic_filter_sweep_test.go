@@ -31,6 +31,8 @@ func init() {
 	resource.AddTestSweepers("ec_deployment_traffic_filter", &resource.Sweeper{
 		Name: "ec_deployment_traffic_filter",
 		F:    testSweepDeploymentTrafficFilter,
+		// Rulesets associated with a deployment can't be deleted.
+		Dependencies: []string{"ec_deployment"},
 	})
 }
 
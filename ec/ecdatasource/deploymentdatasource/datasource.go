@@ -20,15 +20,16 @@ package deploymentdatasource
 import (
 	"time"
 
-	"github.com/elastic/cloud-sdk-go/pkg/api"
-	"github.com/elastic/cloud-sdk-go/pkg/api/deploymentapi"
 	"github.com/elastic/cloud-sdk-go/pkg/api/deploymentapi/deputil"
 	"github.com/elastic/cloud-sdk-go/pkg/models"
 	"github.com/elastic/cloud-sdk-go/pkg/multierror"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 
 	"github.com/elastic/terraform-provider-ec/ec/ecdatasource/deploymentdatasource/state"
+	"github.com/elastic/terraform-provider-ec/ec/ecresource/deploymentresource"
+	"github.com/elastic/terraform-provider-ec/ec/ecresource/deploymentresource/deploymentstate"
 	"github.com/elastic/terraform-provider-ec/ec/ecresource/deploymentresource/elasticsearchstate"
+	"github.com/elastic/terraform-provider-ec/ec/util"
 )
 
 // DataSource returns the ec_deployment data source schema.
@@ -45,18 +46,20 @@ func DataSource() *schema.Resource {
 }
 
 func read(d *schema.ResourceData, meta interface{}) error {
-	client := meta.(*api.API)
+	client := meta.(*util.ProviderMeta).API
 	deploymentID := d.Get("id").(string)
 
-	res, err := deploymentapi.Get(deploymentapi.GetParams{
-		API:          client,
-		DeploymentID: deploymentID,
-		QueryParams: deputil.QueryParams{
-			ShowPlans:        true,
-			ShowSettings:     true,
-			ShowMetadata:     true,
-			ShowPlanDefaults: true,
-		},
+	if d.Get("wait_for_completion").(bool) {
+		if err := deploymentresource.WaitForPlanCompletion(client, deploymentID); err != nil {
+			return multierror.NewPrefixed("failed waiting for deployment plan completion", err)
+		}
+	}
+
+	res, err := util.GetDeployment(client, deploymentID, deputil.QueryParams{
+		ShowPlans:        true,
+		ShowSettings:     true,
+		ShowMetadata:     true,
+		ShowPlanDefaults: true,
 	})
 	if err != nil {
 		return multierror.NewPrefixed("failed retrieving deployment information", err)
@@ -89,6 +92,18 @@ func modelToState(d *schema.ResourceData, res *models.DeploymentGetResponse) err
 			*es.Info.PlanInfo.Current.Plan.DeploymentTemplate.ID); err != nil {
 			return err
 		}
+
+		if plan := es.Info.PlanInfo.Current.Plan; plan.Elasticsearch != nil {
+			if err := d.Set("version", plan.Elasticsearch.Version); err != nil {
+				return err
+			}
+		}
+	}
+
+	if settings := deploymentstate.FlattenTrafficFiltering(res.Settings); settings != nil {
+		if err := d.Set("traffic_filter", settings); err != nil {
+			return err
+		}
 	}
 
 	elasticsearchFlattened := state.FlattenElasticsearchResources(res.Resources.Elasticsearch)
@@ -55,6 +55,11 @@ func Test_modelToState(t *testing.T) {
 					ID:      &mock.ValidClusterID,
 					Healthy: ec.Bool(true),
 					Name:    ec.String("my_deployment_name"),
+					Settings: &models.DeploymentSettings{
+						TrafficFilterSettings: &models.TrafficFilterSettings{
+							Rulesets: []string{"0.0.0.0/0", "192.168.10.0/24"},
+						},
+					},
 					Resources: &models.DeploymentResources{
 						Elasticsearch: []*models.ElasticsearchResourceInfo{
 							{
@@ -67,6 +72,9 @@ func Test_modelToState(t *testing.T) {
 												DeploymentTemplate: &models.DeploymentTemplateReference{
 													ID: ec.String("aws-io-optimized"),
 												},
+												Elasticsearch: &models.ElasticsearchConfiguration{
+													Version: "7.9.1",
+												},
 											},
 										},
 									},
@@ -130,8 +138,11 @@ func newSampleDeployment() map[string]interface{} {
 		"deployment_template_id": "aws-io-optimized",
 		"healthy":                true,
 		"region":                 "us-east-1",
+		"version":                "7.9.1",
+		"traffic_filter":         []interface{}{"0.0.0.0/0", "192.168.10.0/24"},
 		"elasticsearch": []interface{}{map[string]interface{}{
 			"healthy": true,
+			"version": "7.9.1",
 		}},
 		"kibana": []interface{}{map[string]interface{}{
 			"healthy": true,
@@ -31,6 +31,10 @@ func newSchema() map[string]*schema.Schema {
 			Type:     schema.TypeString,
 			Required: true,
 		},
+		"wait_for_completion": {
+			Type:     schema.TypeBool,
+			Optional: true,
+		},
 		"name": {
 			Type:     schema.TypeString,
 			Computed: true,
@@ -43,6 +47,16 @@ func newSchema() map[string]*schema.Schema {
 			Type:     schema.TypeString,
 			Computed: true,
 		},
+		"version": {
+			Type:     schema.TypeString,
+			Computed: true,
+		},
+		"traffic_filter": {
+			Type:     schema.TypeSet,
+			Set:      schema.HashString,
+			Computed: true,
+			Elem:     &schema.Schema{Type: schema.TypeString},
+		},
 
 		// Deployment resources
 		"elasticsearch": {
@@ -0,0 +1,93 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package deploymentsdatasource
+
+import (
+	"strconv"
+
+	"github.com/elastic/cloud-sdk-go/pkg/models"
+	"github.com/elastic/cloud-sdk-go/pkg/util/ec"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+const (
+	// elasticsearchPath is the nested path of the Elasticsearch resources in
+	// the deployments search index.
+	elasticsearchPath = "resources.elasticsearch"
+
+	elasticsearchPlanPath = elasticsearchPath + ".info.plan_info.current.plan"
+)
+
+// expandFilters composes the deployments search query from the data source
+// filters. All the filters must match. The page size is set when searching.
+func expandFilters(d *schema.ResourceData) *models.SearchRequest {
+	var queries []*models.QueryContainer
+
+	if prefix := d.Get("name_prefix").(string); prefix != "" {
+		queries = append(queries, &models.QueryContainer{
+			// The keyword field is used, so the prefix isn't analyzed.
+			Prefix: map[string]models.PrefixQuery{
+				"name.keyword": {Value: ec.String(prefix)},
+			},
+		})
+	}
+
+	if id := d.Get("deployment_template_id").(string); id != "" {
+		queries = append(queries, newNestedTermQuery(
+			elasticsearchPath, elasticsearchPlanPath+".deployment_template.id", id,
+		))
+	}
+
+	if version := d.Get("version").(string); version != "" {
+		queries = append(queries, newNestedTermQuery(
+			elasticsearchPath, elasticsearchPlanPath+".elasticsearch.version", version,
+		))
+	}
+
+	if healthy := d.Get("healthy").(string); healthy != "" {
+		// The value is validated by the schema.
+		value, _ := strconv.ParseBool(healthy)
+		queries = append(queries, &models.QueryContainer{
+			Term: map[string]models.TermQuery{"healthy": {Value: value}},
+		})
+	}
+
+	var req = models.SearchRequest{
+		// Sorting by ID keeps the results in a stable order across pages.
+		Sort: []interface{}{"id"},
+	}
+
+	if len(queries) > 0 {
+		req.Query = &models.QueryContainer{
+			Bool: &models.BoolQuery{Filter: queries},
+		}
+	}
+
+	return &req
+}
+
+func newNestedTermQuery(path, field string, value interface{}) *models.QueryContainer {
+	return &models.QueryContainer{
+		Nested: &models.NestedQuery{
+			Path: ec.String(path),
+			Query: &models.QueryContainer{
+				Term: map[string]models.TermQuery{field: {Value: value}},
+			},
+		},
+	}
+}
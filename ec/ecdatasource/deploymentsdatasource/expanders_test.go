@@ -0,0 +1,132 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package deploymentsdatasource
+
+import (
+	"testing"
+
+	"github.com/elastic/cloud-sdk-go/pkg/models"
+	"github.com/elastic/cloud-sdk-go/pkg/util/ec"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_expandFilters(t *testing.T) {
+	type args struct {
+		d *schema.ResourceData
+	}
+	tests := []struct {
+		name string
+		args args
+		want *models.SearchRequest
+	}{
+		{
+			name: "returns a request without query when no filters are set",
+			args: args{d: newResourceData(t, resDataParams{
+				Resources: map[string]interface{}{},
+			})},
+			want: &models.SearchRequest{
+				Sort: []interface{}{"id"},
+			},
+		},
+		{
+			name: "composes the query from the filters",
+			args: args{d: newResourceData(t, resDataParams{
+				Resources: map[string]interface{}{
+					"name_prefix":            "test",
+					"deployment_template_id": "aws-io-optimized-v2",
+					"version":                "7.10.1",
+				},
+			})},
+			want: &models.SearchRequest{
+				Sort: []interface{}{"id"},
+				Query: &models.QueryContainer{
+					Bool: &models.BoolQuery{Filter: []*models.QueryContainer{
+						{
+							Prefix: map[string]models.PrefixQuery{
+								"name.keyword": {Value: ec.String("test")},
+							},
+						},
+						{
+							Nested: &models.NestedQuery{
+								Path: ec.String("resources.elasticsearch"),
+								Query: &models.QueryContainer{
+									Term: map[string]models.TermQuery{
+										"resources.elasticsearch.info.plan_info.current.plan.deployment_template.id": {
+											Value: "aws-io-optimized-v2",
+										},
+									},
+								},
+							},
+						},
+						{
+							Nested: &models.NestedQuery{
+								Path: ec.String("resources.elasticsearch"),
+								Query: &models.QueryContainer{
+									Term: map[string]models.TermQuery{
+										"resources.elasticsearch.info.plan_info.current.plan.elasticsearch.version": {
+											Value: "7.10.1",
+										},
+									},
+								},
+							},
+						},
+					}},
+				},
+			},
+		},
+		{
+			name: "composes the query from the health filter",
+			args: args{d: newResourceData(t, resDataParams{
+				Resources: map[string]interface{}{
+					"healthy": "false",
+				},
+			})},
+			want: &models.SearchRequest{
+				Sort: []interface{}{"id"},
+				Query: &models.QueryContainer{
+					Bool: &models.BoolQuery{Filter: []*models.QueryContainer{
+						{
+							Term: map[string]models.TermQuery{
+								"healthy": {Value: false},
+							},
+						},
+					}},
+				},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := expandFilters(tt.args.d)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+type resDataParams struct {
+	Resources map[string]interface{}
+	ID        string
+}
+
+func newResourceData(t *testing.T, params resDataParams) *schema.ResourceData {
+	raw := schema.TestResourceDataRaw(t, DataSource().Schema, params.Resources)
+	raw.SetId(params.ID)
+
+	return raw
+}
@@ -0,0 +1,91 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package deploymentsdatasource
+
+import (
+	"github.com/elastic/cloud-sdk-go/pkg/models"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+
+	"github.com/elastic/terraform-provider-ec/ec/ecresource/deploymentresource/elasticsearchstate"
+)
+
+func modelToState(d *schema.ResourceData, res *models.DeploymentsSearchResponse) error {
+	if res == nil {
+		return nil
+	}
+
+	var deployments = flattenDeployments(res.Deployments)
+	if err := d.Set("deployment_count", len(deployments)); err != nil {
+		return err
+	}
+
+	return d.Set("deployments", deployments)
+}
+
+func flattenDeployments(in []*models.DeploymentSearchResponse) []interface{} {
+	var result = make([]interface{}, 0, len(in))
+	for _, deployment := range in {
+		if deployment == nil || deployment.ID == nil {
+			continue
+		}
+
+		var m = map[string]interface{}{
+			"deployment_id": *deployment.ID,
+		}
+
+		if deployment.Name != nil {
+			m["name"] = *deployment.Name
+		}
+
+		if deployment.Healthy != nil {
+			m["healthy"] = *deployment.Healthy
+		}
+
+		if deployment.Resources != nil && len(deployment.Resources.Elasticsearch) > 0 {
+			for k, v := range flattenElasticsearch(deployment.Resources.Elasticsearch[0]) {
+				m[k] = v
+			}
+		}
+
+		result = append(result, m)
+	}
+
+	return result
+}
+
+func flattenElasticsearch(res *models.ElasticsearchResourceInfo) map[string]interface{} {
+	var m = make(map[string]interface{})
+	if res.Info != nil && res.Info.ClusterID != nil {
+		m["elasticsearch_resource_id"] = *res.Info.ClusterID
+	}
+
+	if elasticsearchstate.IsCurrentPlanEmpty(res) {
+		return m
+	}
+
+	var plan = res.Info.PlanInfo.Current.Plan
+	if plan.DeploymentTemplate != nil && plan.DeploymentTemplate.ID != nil {
+		m["deployment_template_id"] = *plan.DeploymentTemplate.ID
+	}
+
+	if plan.Elasticsearch != nil {
+		m["version"] = plan.Elasticsearch.Version
+	}
+
+	return m
+}
@@ -0,0 +1,121 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package deploymentsdatasource
+
+import (
+	"testing"
+
+	"github.com/elastic/cloud-sdk-go/pkg/models"
+	"github.com/elastic/cloud-sdk-go/pkg/util/ec"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_modelToState(t *testing.T) {
+	deploymentsSchemaArg := schema.TestResourceDataRaw(t, newSchema(), nil)
+	deploymentsSchemaArg.SetId("someid")
+	_ = deploymentsSchemaArg.Set("name_prefix", "test")
+
+	wantDeployments := newResourceData(t, resDataParams{
+		ID: "someid",
+		Resources: map[string]interface{}{
+			"name_prefix":      "test",
+			"deployment_count": 2,
+			"deployments": []interface{}{
+				map[string]interface{}{
+					"deployment_id":             "some-id",
+					"name":                      "test-hot-warm",
+					"healthy":                   true,
+					"deployment_template_id":    "aws-hot-warm-v2",
+					"version":                   "7.10.1",
+					"elasticsearch_resource_id": "some-es-id",
+				},
+				map[string]interface{}{
+					"deployment_id":             "some-other-id",
+					"name":                      "test-pending",
+					"healthy":                   false,
+					"deployment_template_id":    "",
+					"version":                   "",
+					"elasticsearch_resource_id": "",
+				},
+			},
+		},
+	})
+
+	type args struct {
+		d   *schema.ResourceData
+		res *models.DeploymentsSearchResponse
+	}
+	tests := []struct {
+		name string
+		args args
+		want *schema.ResourceData
+		err  error
+	}{
+		{
+			name: "flattens the matching deployments",
+			want: wantDeployments,
+			args: args{
+				d: deploymentsSchemaArg,
+				res: &models.DeploymentsSearchResponse{Deployments: []*models.DeploymentSearchResponse{
+					{
+						ID:      ec.String("some-id"),
+						Name:    ec.String("test-hot-warm"),
+						Healthy: ec.Bool(true),
+						Resources: &models.DeploymentResources{
+							Elasticsearch: []*models.ElasticsearchResourceInfo{{
+								Info: &models.ElasticsearchClusterInfo{
+									ClusterID: ec.String("some-es-id"),
+									PlanInfo: &models.ElasticsearchClusterPlansInfo{
+										Current: &models.ElasticsearchClusterPlanInfo{
+											Plan: &models.ElasticsearchClusterPlan{
+												DeploymentTemplate: &models.DeploymentTemplateReference{
+													ID: ec.String("aws-hot-warm-v2"),
+												},
+												Elasticsearch: &models.ElasticsearchConfiguration{
+													Version: "7.10.1",
+												},
+											},
+										},
+									},
+								},
+							}},
+						},
+					},
+					{
+						ID:      ec.String("some-other-id"),
+						Name:    ec.String("test-pending"),
+						Healthy: ec.Bool(false),
+					},
+				}},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := modelToState(tt.args.d, tt.args.res)
+			if tt.err != nil {
+				assert.EqualError(t, err, tt.err.Error())
+			} else {
+				assert.NoError(t, err)
+			}
+
+			assert.Equal(t, tt.want.State().Attributes, tt.args.d.State().Attributes)
+		})
+	}
+}
@@ -0,0 +1,67 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package deploymentsdatasource
+
+import (
+	"github.com/elastic/cloud-sdk-go/pkg/models"
+)
+
+// searchPageSize is the number of deployments requested on each page.
+var searchPageSize int32 = 100
+
+type searchFunc func(*models.SearchRequest) (*models.DeploymentsSearchResponse, error)
+
+// searchAll pages through the search results until all the matching
+// deployments, or limit deployments when it's greater than zero, are
+// obtained. The request is modified to request each page.
+func searchAll(search searchFunc, req *models.SearchRequest, limit int) (*models.DeploymentsSearchResponse, error) {
+	var result models.DeploymentsSearchResponse
+	for {
+		req.Size = searchPageSize
+		if remaining := limit - len(result.Deployments); limit > 0 && int32(remaining) < req.Size {
+			req.Size = int32(remaining)
+		}
+		req.From = int32(len(result.Deployments))
+
+		res, err := search(req)
+		if err != nil {
+			return nil, err
+		}
+
+		result.Deployments = append(result.Deployments, res.Deployments...)
+		result.MatchCount = res.MatchCount
+
+		// The match count is omitted from some responses, so it's only used
+		// to stop early when it's set. A short page is always the last one.
+		var returned = int32(len(res.Deployments))
+		var done = returned < req.Size ||
+			(res.MatchCount > 0 && int32(len(result.Deployments)) >= res.MatchCount) ||
+			(limit > 0 && len(result.Deployments) >= limit)
+		if done {
+			break
+		}
+	}
+
+	var count = int32(len(result.Deployments))
+	result.ReturnCount = &count
+	if result.MatchCount == 0 {
+		result.MatchCount = count
+	}
+
+	return &result, nil
+}
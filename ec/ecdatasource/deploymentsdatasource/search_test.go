@@ -0,0 +1,125 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package deploymentsdatasource
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/elastic/cloud-sdk-go/pkg/models"
+	"github.com/elastic/cloud-sdk-go/pkg/util/ec"
+	"github.com/stretchr/testify/assert"
+)
+
+// newSearch returns a searchFunc which pages through total deployments,
+// recording the requested pages. The match count is omitted from the responses
+// when withoutMatchCount is set.
+func newSearch(total int, withoutMatchCount bool, pages *[]string) searchFunc {
+	return func(req *models.SearchRequest) (*models.DeploymentsSearchResponse, error) {
+		*pages = append(*pages, fmt.Sprintf("%d:%d", req.From, req.Size))
+
+		var res models.DeploymentsSearchResponse
+		if !withoutMatchCount {
+			res.MatchCount = int32(total)
+		}
+		for i := int(req.From); i < total && i < int(req.From+req.Size); i++ {
+			res.Deployments = append(res.Deployments, &models.DeploymentSearchResponse{
+				ID: ec.String(fmt.Sprint(i)),
+			})
+		}
+		return &res, nil
+	}
+}
+
+func Test_searchAll(t *testing.T) {
+	searchPageSize = 10
+	tests := []struct {
+		name  string
+		total int
+		limit int
+		// withoutMatchCount omits the match_count from the responses.
+		withoutMatchCount bool
+		wantCount         int
+		wantPages         []string
+	}{
+		{
+			name:      "returns the deployments in a single page",
+			total:     5,
+			wantCount: 5,
+			wantPages: []string{"0:10"},
+		},
+		{
+			name:      "pages through all the deployments",
+			total:     25,
+			wantCount: 25,
+			wantPages: []string{"0:10", "10:10", "20:10"},
+		},
+		{
+			name:      "stops when the matching deployments are a multiple of the page size",
+			total:     20,
+			wantCount: 20,
+			wantPages: []string{"0:10", "10:10"},
+		},
+		{
+			name:      "stops at the limit",
+			total:     25,
+			limit:     15,
+			wantCount: 15,
+			wantPages: []string{"0:10", "10:5"},
+		},
+		{
+			name:              "pages through all the deployments when the responses have no match_count",
+			total:             25,
+			withoutMatchCount: true,
+			wantCount:         25,
+			wantPages:         []string{"0:10", "10:10", "20:10"},
+		},
+		{
+			name:              "stops on an empty page when the responses have no match_count",
+			total:             20,
+			withoutMatchCount: true,
+			wantCount:         20,
+			wantPages:         []string{"0:10", "10:10", "20:10"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var pages []string
+			got, err := searchAll(newSearch(tt.total, tt.withoutMatchCount, &pages), &models.SearchRequest{}, tt.limit)
+			assert.NoError(t, err)
+			assert.Len(t, got.Deployments, tt.wantCount)
+			assert.Equal(t, int32(tt.wantCount), *got.ReturnCount)
+			if tt.limit == 0 {
+				assert.Equal(t, int32(tt.total), got.MatchCount)
+			}
+			assert.Equal(t, tt.wantPages, pages)
+
+			for i, deployment := range got.Deployments {
+				assert.Equal(t, fmt.Sprint(i), *deployment.ID)
+			}
+		})
+	}
+
+	t.Run("returns the search error", func(t *testing.T) {
+		_, err := searchAll(func(*models.SearchRequest) (*models.DeploymentsSearchResponse, error) {
+			return nil, errors.New("some error")
+		}, &models.SearchRequest{}, 0)
+		assert.EqualError(t, err, "some error")
+	})
+}
@@ -0,0 +1,178 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package deploymenttemplatedatasource
+
+import (
+	"github.com/elastic/cloud-sdk-go/pkg/models"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+
+	"github.com/elastic/terraform-provider-ec/ec/util"
+)
+
+func modelToState(d *schema.ResourceData, res *models.DeploymentTemplateInfoV2) error {
+	if res == nil {
+		return nil
+	}
+
+	if res.Name != nil {
+		if err := d.Set("name", *res.Name); err != nil {
+			return err
+		}
+	}
+
+	if err := d.Set("description", res.Description); err != nil {
+		return err
+	}
+
+	if err := d.Set("min_version", res.MinVersion); err != nil {
+		return err
+	}
+
+	var resources = new(models.DeploymentCreateResources)
+	if res.DeploymentTemplate != nil && res.DeploymentTemplate.Resources != nil {
+		resources = res.DeploymentTemplate.Resources
+	}
+
+	if err := d.Set("elasticsearch_topology", flattenEsTopology(resources.Elasticsearch)); err != nil {
+		return err
+	}
+
+	if err := d.Set("kibana_topology", flattenKibanaTopology(resources.Kibana)); err != nil {
+		return err
+	}
+
+	if err := d.Set("apm_topology", flattenApmTopology(resources.Apm)); err != nil {
+		return err
+	}
+
+	return d.Set("enterprise_search_topology", flattenEssTopology(resources.EnterpriseSearch))
+}
+
+func flattenEsTopology(in []*models.ElasticsearchPayload) []interface{} {
+	var result = make([]interface{}, 0)
+	for _, res := range in {
+		if res == nil || res.Plan == nil {
+			continue
+		}
+
+		for _, t := range res.Plan.ClusterTopology {
+			if t == nil {
+				continue
+			}
+
+			var m = flattenTopologyElement(t.InstanceConfigurationID, t.Size, t.ZoneCount)
+			if nt := t.NodeType; nt != nil {
+				if nt.Data != nil {
+					m["node_type_data"] = *nt.Data
+				}
+
+				if nt.Master != nil {
+					m["node_type_master"] = *nt.Master
+				}
+
+				if nt.Ingest != nil {
+					m["node_type_ingest"] = *nt.Ingest
+				}
+
+				if nt.Ml != nil {
+					m["node_type_ml"] = *nt.Ml
+				}
+			}
+
+			result = append(result, m)
+		}
+	}
+
+	return result
+}
+
+func flattenKibanaTopology(in []*models.KibanaPayload) []interface{} {
+	var result = make([]interface{}, 0)
+	for _, res := range in {
+		if res == nil || res.Plan == nil {
+			continue
+		}
+
+		for _, t := range res.Plan.ClusterTopology {
+			if t == nil {
+				continue
+			}
+			result = append(result, flattenTopologyElement(
+				t.InstanceConfigurationID, t.Size, t.ZoneCount,
+			))
+		}
+	}
+
+	return result
+}
+
+func flattenApmTopology(in []*models.ApmPayload) []interface{} {
+	var result = make([]interface{}, 0)
+	for _, res := range in {
+		if res == nil || res.Plan == nil {
+			continue
+		}
+
+		for _, t := range res.Plan.ClusterTopology {
+			if t == nil {
+				continue
+			}
+			result = append(result, flattenTopologyElement(
+				t.InstanceConfigurationID, t.Size, t.ZoneCount,
+			))
+		}
+	}
+
+	return result
+}
+
+func flattenEssTopology(in []*models.EnterpriseSearchPayload) []interface{} {
+	var result = make([]interface{}, 0)
+	for _, res := range in {
+		if res == nil || res.Plan == nil {
+			continue
+		}
+
+		for _, t := range res.Plan.ClusterTopology {
+			if t == nil {
+				continue
+			}
+			result = append(result, flattenTopologyElement(
+				t.InstanceConfigurationID, t.Size, t.ZoneCount,
+			))
+		}
+	}
+
+	return result
+}
+
+// flattenTopologyElement flattens the fields shared by the topology elements
+// of all the resource kinds. Unlike the deployment resource, elements with a
+// zero size are kept, since they're the template's optional topologies.
+func flattenTopologyElement(id string, size *models.TopologySize, zoneCount int32) map[string]interface{} {
+	var m = map[string]interface{}{
+		"instance_configuration_id": id,
+		"zone_count":                zoneCount,
+	}
+
+	if size != nil && size.Value != nil && size.Resource != nil && *size.Resource == "memory" {
+		m["memory_per_node"] = util.MemoryToState(*size.Value)
+	}
+
+	return m
+}
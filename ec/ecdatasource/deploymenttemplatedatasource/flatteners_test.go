@@ -0,0 +1,184 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package deploymenttemplatedatasource
+
+import (
+	"testing"
+
+	"github.com/elastic/cloud-sdk-go/pkg/models"
+	"github.com/elastic/cloud-sdk-go/pkg/util/ec"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_modelToState(t *testing.T) {
+	templateSchemaArg := schema.TestResourceDataRaw(t, newSchema(), nil)
+	templateSchemaArg.SetId("aws-io-optimized")
+	_ = templateSchemaArg.Set("id", "aws-io-optimized")
+	_ = templateSchemaArg.Set("region", "us-east-1")
+
+	wantTemplate := newResourceData(t, resDataParams{
+		ID:        "aws-io-optimized",
+		Resources: newSampleTemplate(),
+	})
+	// The sample template has no enterprise search topology.
+	_ = wantTemplate.Set("enterprise_search_topology", []interface{}{})
+
+	type args struct {
+		d   *schema.ResourceData
+		res *models.DeploymentTemplateInfoV2
+	}
+	tests := []struct {
+		name string
+		args args
+		want *schema.ResourceData
+		err  error
+	}{
+		{
+			name: "flattens the deployment template default topologies",
+			want: wantTemplate,
+			args: args{
+				d: templateSchemaArg,
+				res: &models.DeploymentTemplateInfoV2{
+					ID:          ec.String("aws-io-optimized"),
+					Name:        ec.String("I/O Optimized"),
+					Description: "Great for general purpose workloads.",
+					MinVersion:  "6.0.0",
+					DeploymentTemplate: &models.DeploymentCreateRequest{
+						Resources: &models.DeploymentCreateResources{
+							Elasticsearch: []*models.ElasticsearchPayload{{
+								Plan: &models.ElasticsearchClusterPlan{
+									ClusterTopology: []*models.ElasticsearchClusterTopologyElement{
+										{
+											InstanceConfigurationID: "aws.data.highio.i3",
+											ZoneCount:               2,
+											Size: &models.TopologySize{
+												Resource: ec.String("memory"),
+												Value:    ec.Int32(8192),
+											},
+											NodeType: &models.ElasticsearchNodeType{
+												Data:   ec.Bool(true),
+												Master: ec.Bool(true),
+												Ingest: ec.Bool(true),
+											},
+										},
+										{
+											InstanceConfigurationID: "aws.ml.m5",
+											ZoneCount:               1,
+											Size: &models.TopologySize{
+												Resource: ec.String("memory"),
+												Value:    ec.Int32(0),
+											},
+											NodeType: &models.ElasticsearchNodeType{
+												Ml: ec.Bool(true),
+											},
+										},
+									},
+								},
+							}},
+							Kibana: []*models.KibanaPayload{{
+								Plan: &models.KibanaClusterPlan{
+									ClusterTopology: []*models.KibanaClusterTopologyElement{{
+										InstanceConfigurationID: "aws.kibana.r5d",
+										ZoneCount:               1,
+										Size: &models.TopologySize{
+											Resource: ec.String("memory"),
+											Value:    ec.Int32(1024),
+										},
+									}},
+								},
+							}},
+							Apm: []*models.ApmPayload{{
+								Plan: &models.ApmPlan{
+									ClusterTopology: []*models.ApmTopologyElement{{
+										InstanceConfigurationID: "aws.apm.r5d",
+										ZoneCount:               1,
+										Size: &models.TopologySize{
+											Resource: ec.String("memory"),
+											Value:    ec.Int32(512),
+										},
+									}},
+								},
+							}},
+						},
+					},
+				},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := modelToState(tt.args.d, tt.args.res)
+			if tt.err != nil {
+				assert.EqualError(t, err, tt.err.Error())
+			} else {
+				assert.NoError(t, err)
+			}
+
+			assert.Equal(t, tt.want.State().Attributes, tt.args.d.State().Attributes)
+		})
+	}
+}
+
+type resDataParams struct {
+	Resources map[string]interface{}
+	ID        string
+}
+
+func newResourceData(t *testing.T, params resDataParams) *schema.ResourceData {
+	raw := schema.TestResourceDataRaw(t, DataSource().Schema, params.Resources)
+	raw.SetId(params.ID)
+
+	return raw
+}
+
+func newSampleTemplate() map[string]interface{} {
+	return map[string]interface{}{
+		"id":          "aws-io-optimized",
+		"region":      "us-east-1",
+		"name":        "I/O Optimized",
+		"description": "Great for general purpose workloads.",
+		"min_version": "6.0.0",
+		"elasticsearch_topology": []interface{}{
+			map[string]interface{}{
+				"instance_configuration_id": "aws.data.highio.i3",
+				"memory_per_node":           "8g",
+				"zone_count":                2,
+				"node_type_data":            true,
+				"node_type_master":          true,
+				"node_type_ingest":          true,
+			},
+			map[string]interface{}{
+				"instance_configuration_id": "aws.ml.m5",
+				"memory_per_node":           "0g",
+				"zone_count":                1,
+				"node_type_ml":              true,
+			},
+		},
+		"kibana_topology": []interface{}{map[string]interface{}{
+			"instance_configuration_id": "aws.kibana.r5d",
+			"memory_per_node":           "1g",
+			"zone_count":                1,
+		}},
+		"apm_topology": []interface{}{map[string]interface{}{
+			"instance_configuration_id": "aws.apm.r5d",
+			"memory_per_node":           "0.5g",
+			"zone_count":                1,
+		}},
+	}
+}
@@ -0,0 +1,103 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package deploymenttemplatedatasource
+
+import (
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func newSchema() map[string]*schema.Schema {
+	return map[string]*schema.Schema{
+		"id": {
+			Type:     schema.TypeString,
+			Required: true,
+		},
+		"region": {
+			Type:     schema.TypeString,
+			Required: true,
+		},
+		"stack_version": {
+			Type:     schema.TypeString,
+			Optional: true,
+		},
+
+		// Exported attributes
+		"name": {
+			Type:     schema.TypeString,
+			Computed: true,
+		},
+		"description": {
+			Type:     schema.TypeString,
+			Computed: true,
+		},
+		"min_version": {
+			Type:     schema.TypeString,
+			Computed: true,
+		},
+		"elasticsearch_topology": newTopologySchema(map[string]*schema.Schema{
+			"node_type_data": {
+				Type:     schema.TypeBool,
+				Computed: true,
+			},
+			"node_type_master": {
+				Type:     schema.TypeBool,
+				Computed: true,
+			},
+			"node_type_ingest": {
+				Type:     schema.TypeBool,
+				Computed: true,
+			},
+			"node_type_ml": {
+				Type:     schema.TypeBool,
+				Computed: true,
+			},
+		}),
+		"kibana_topology":            newTopologySchema(nil),
+		"apm_topology":               newTopologySchema(nil),
+		"enterprise_search_topology": newTopologySchema(nil),
+	}
+}
+
+// newTopologySchema returns the schema of the default topology elements of a
+// resource kind, extended with the kind specific fields.
+func newTopologySchema(extra map[string]*schema.Schema) *schema.Schema {
+	var fields = map[string]*schema.Schema{
+		"instance_configuration_id": {
+			Type:     schema.TypeString,
+			Computed: true,
+		},
+		"memory_per_node": {
+			Type:     schema.TypeString,
+			Computed: true,
+		},
+		"zone_count": {
+			Type:     schema.TypeInt,
+			Computed: true,
+		},
+	}
+
+	for k, v := range extra {
+		fields[k] = v
+	}
+
+	return &schema.Schema{
+		Type:     schema.TypeList,
+		Computed: true,
+		Elem:     &schema.Resource{Schema: fields},
+	}
+}
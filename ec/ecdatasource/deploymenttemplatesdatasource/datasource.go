@@ -0,0 +1,158 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package deploymenttemplatesdatasource
+
+import (
+	"context"
+	"strconv"
+	"time"
+
+	"github.com/elastic/cloud-sdk-go/pkg/api/deploymentapi/deptemplateapi"
+	"github.com/elastic/cloud-sdk-go/pkg/models"
+	"github.com/elastic/cloud-sdk-go/pkg/multierror"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+
+	"github.com/elastic/terraform-provider-ec/ec/util"
+)
+
+// DataSource returns the ec_deployment_templates data source schema.
+func DataSource() *schema.Resource {
+	return &schema.Resource{
+		ReadContext: read,
+
+		Schema: newSchema(),
+
+		Timeouts: &schema.ResourceTimeout{
+			Default: schema.DefaultTimeout(5 * time.Minute),
+		},
+	}
+}
+
+func read(_ context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client := meta.(*util.ProviderMeta).API
+	region := d.Get("region").(string)
+	stackVersion := d.Get("stack_version").(string)
+	showHidden := d.Get("show_hidden").(bool)
+
+	res, err := deptemplateapi.List(deptemplateapi.ListParams{
+		API:          client,
+		Region:       region,
+		StackVersion: stackVersion,
+		ShowHidden:   showHidden,
+	})
+	if err != nil {
+		return diag.FromErr(
+			multierror.NewPrefixed("failed listing deployment templates", err),
+		)
+	}
+
+	if d.Id() == "" {
+		d.SetId(strconv.Itoa(schema.HashString(
+			region + stackVersion + strconv.FormatBool(showHidden),
+		)))
+	}
+
+	if err := d.Set("templates", flattenTemplates(res)); err != nil {
+		return diag.FromErr(err)
+	}
+
+	return nil
+}
+
+func flattenTemplates(in []*models.DeploymentTemplateInfoV2) []interface{} {
+	var result = make([]interface{}, 0, len(in))
+	for _, tpl := range in {
+		if tpl == nil || tpl.ID == nil {
+			continue
+		}
+
+		var m = map[string]interface{}{
+			"id":                         *tpl.ID,
+			"description":                tpl.Description,
+			"min_version":                tpl.MinVersion,
+			"template_category_id":       tpl.TemplateCategoryID,
+			"metadata":                   flattenMetadata(tpl.Metadata),
+			"resource_kinds":             flattenResourceKinds(tpl.DeploymentTemplate),
+			"instance_configuration_ids": flattenInstanceConfigurationIDs(tpl.InstanceConfigurations),
+		}
+
+		if tpl.Name != nil {
+			m["name"] = *tpl.Name
+		}
+
+		if tpl.Hidden != nil {
+			m["hidden"] = *tpl.Hidden
+		}
+
+		result = append(result, m)
+	}
+
+	return result
+}
+
+func flattenMetadata(in []*models.MetadataItem) map[string]interface{} {
+	var result = make(map[string]interface{}, len(in))
+	for _, item := range in {
+		if item == nil || item.Key == nil || item.Value == nil {
+			continue
+		}
+		result[*item.Key] = *item.Value
+	}
+
+	return result
+}
+
+// flattenResourceKinds returns the resource kinds which the deployment
+// template offers, in the order of the deployment resource blocks.
+func flattenResourceKinds(tpl *models.DeploymentCreateRequest) []interface{} {
+	var result = make([]interface{}, 0, 4)
+	if tpl == nil || tpl.Resources == nil {
+		return result
+	}
+
+	if len(tpl.Resources.Elasticsearch) > 0 {
+		result = append(result, "elasticsearch")
+	}
+
+	if len(tpl.Resources.Kibana) > 0 {
+		result = append(result, "kibana")
+	}
+
+	if len(tpl.Resources.Apm) > 0 {
+		result = append(result, "apm")
+	}
+
+	if len(tpl.Resources.EnterpriseSearch) > 0 {
+		result = append(result, "enterprise_search")
+	}
+
+	return result
+}
+
+func flattenInstanceConfigurationIDs(in []*models.InstanceConfigurationInfo) []interface{} {
+	var result = make([]interface{}, 0, len(in))
+	for _, ic := range in {
+		if ic == nil || ic.ID == "" {
+			continue
+		}
+		result = append(result, ic.ID)
+	}
+
+	return result
+}
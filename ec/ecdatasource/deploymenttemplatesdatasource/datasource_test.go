@@ -0,0 +1,112 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package deploymenttemplatesdatasource
+
+import (
+	"testing"
+
+	"github.com/elastic/cloud-sdk-go/pkg/models"
+	"github.com/elastic/cloud-sdk-go/pkg/util/ec"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_flattenTemplates(t *testing.T) {
+	type args struct {
+		in []*models.DeploymentTemplateInfoV2
+	}
+	tests := []struct {
+		name string
+		args args
+		want []interface{}
+	}{
+		{
+			name: "empty templates flatten to an empty list",
+			args: args{},
+			want: []interface{}{},
+		},
+		{
+			name: "flattens the templates",
+			args: args{in: []*models.DeploymentTemplateInfoV2{
+				{
+					ID:                 ec.String("aws-io-optimized"),
+					Name:               ec.String("I/O Optimized"),
+					Description:        "Great for general purpose workloads.",
+					MinVersion:         "6.0.0",
+					TemplateCategoryID: "io-optimized",
+					Hidden:             ec.Bool(false),
+					Metadata: []*models.MetadataItem{
+						{Key: ec.String("parent_solution"), Value: ec.String("stack")},
+						{Key: ec.String("hidden")},
+					},
+					DeploymentTemplate: &models.DeploymentCreateRequest{
+						Resources: &models.DeploymentCreateResources{
+							Elasticsearch: []*models.ElasticsearchPayload{{}},
+							Kibana:        []*models.KibanaPayload{{}},
+							Apm:           []*models.ApmPayload{{}},
+						},
+					},
+					InstanceConfigurations: []*models.InstanceConfigurationInfo{
+						{ID: "aws.data.highio.i3"},
+						{ID: "aws.kibana.r5d"},
+						{},
+					},
+				},
+				{
+					ID:   ec.String("aws-compute-optimized"),
+					Name: ec.String("Compute Optimized"),
+				},
+				{Name: ec.String("no id")},
+			}},
+			want: []interface{}{
+				map[string]interface{}{
+					"id":                   "aws-io-optimized",
+					"name":                 "I/O Optimized",
+					"description":          "Great for general purpose workloads.",
+					"min_version":          "6.0.0",
+					"template_category_id": "io-optimized",
+					"hidden":               false,
+					"metadata": map[string]interface{}{
+						"parent_solution": "stack",
+					},
+					"resource_kinds": []interface{}{
+						"elasticsearch", "kibana", "apm",
+					},
+					"instance_configuration_ids": []interface{}{
+						"aws.data.highio.i3", "aws.kibana.r5d",
+					},
+				},
+				map[string]interface{}{
+					"id":                         "aws-compute-optimized",
+					"name":                       "Compute Optimized",
+					"description":                "",
+					"min_version":                "",
+					"template_category_id":       "",
+					"metadata":                   map[string]interface{}{},
+					"resource_kinds":             []interface{}{},
+					"instance_configuration_ids": []interface{}{},
+				},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := flattenTemplates(tt.args.in)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
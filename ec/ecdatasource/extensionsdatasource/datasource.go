@@ -0,0 +1,119 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package extensionsdatasource
+
+import (
+	"context"
+	"strconv"
+	"time"
+
+	"github.com/elastic/cloud-sdk-go/pkg/api"
+	"github.com/elastic/cloud-sdk-go/pkg/client/extensions"
+	"github.com/elastic/cloud-sdk-go/pkg/models"
+	"github.com/elastic/cloud-sdk-go/pkg/multierror"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+
+	"github.com/elastic/terraform-provider-ec/ec/util"
+)
+
+// DataSource returns the ec_extensions data source schema.
+func DataSource() *schema.Resource {
+	return &schema.Resource{
+		ReadContext: read,
+
+		Schema: newSchema(),
+
+		Timeouts: &schema.ResourceTimeout{
+			Default: schema.DefaultTimeout(5 * time.Minute),
+		},
+	}
+}
+
+func read(_ context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client := meta.(*util.ProviderMeta).API
+	extensionType := d.Get("extension_type").(string)
+
+	res, err := client.V1API.Extensions.ListExtensions(
+		extensions.NewListExtensionsParams(), client.AuthWriter,
+	)
+	if err != nil {
+		return diag.FromErr(
+			multierror.NewPrefixed("failed listing extensions", api.UnwrapError(err)),
+		)
+	}
+
+	if d.Id() == "" {
+		d.SetId(strconv.Itoa(schema.HashString(extensionType)))
+	}
+
+	if err := modelToState(d, res.Payload, extensionType); err != nil {
+		return diag.FromErr(err)
+	}
+
+	return nil
+}
+
+func modelToState(d *schema.ResourceData, res *models.Extensions, extensionType string) error {
+	if res == nil {
+		return nil
+	}
+
+	return d.Set("extensions", flattenExtensions(res.Extensions, extensionType))
+}
+
+// flattenExtensions flattens the extensions, only keeping the ones of the
+// extension type when it's set.
+func flattenExtensions(in []*models.Extension, extensionType string) []interface{} {
+	var result = make([]interface{}, 0, len(in))
+	for _, ext := range in {
+		if ext == nil || ext.ID == nil {
+			continue
+		}
+
+		if extensionType != "" && (ext.ExtensionType == nil || *ext.ExtensionType != extensionType) {
+			continue
+		}
+
+		var m = map[string]interface{}{
+			"id":           *ext.ID,
+			"description":  ext.Description,
+			"download_url": ext.DownloadURL,
+		}
+
+		if ext.Name != nil {
+			m["name"] = *ext.Name
+		}
+
+		if ext.ExtensionType != nil {
+			m["extension_type"] = *ext.ExtensionType
+		}
+
+		if ext.Version != nil {
+			m["version"] = *ext.Version
+		}
+
+		if ext.URL != nil {
+			m["url"] = *ext.URL
+		}
+
+		result = append(result, m)
+	}
+
+	return result
+}
@@ -0,0 +1,85 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package extensionsdatasource
+
+import (
+	"testing"
+
+	"github.com/elastic/cloud-sdk-go/pkg/models"
+	"github.com/elastic/cloud-sdk-go/pkg/util/ec"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_flattenExtensions(t *testing.T) {
+	var exts = []*models.Extension{
+		{
+			ID:            ec.String("some-id"),
+			Name:          ec.String("synonyms"),
+			ExtensionType: ec.String("bundle"),
+			Version:       ec.String("7.*"),
+			Description:   "company synonyms",
+			URL:           ec.String("repo://some-id"),
+		},
+		{
+			ID:            ec.String("some-other-id"),
+			Name:          ec.String("my-plugin"),
+			ExtensionType: ec.String("plugin"),
+			Version:       ec.String("7.10.1"),
+			DownloadURL:   "https://example.com/my-plugin.zip",
+			URL:           ec.String("repo://some-other-id"),
+		},
+	}
+	var bundle = map[string]interface{}{
+		"id":             "some-id",
+		"name":           "synonyms",
+		"extension_type": "bundle",
+		"version":        "7.*",
+		"description":    "company synonyms",
+		"download_url":   "",
+		"url":            "repo://some-id",
+	}
+	var plugin = map[string]interface{}{
+		"id":             "some-other-id",
+		"name":           "my-plugin",
+		"extension_type": "plugin",
+		"version":        "7.10.1",
+		"description":    "",
+		"download_url":   "https://example.com/my-plugin.zip",
+		"url":            "repo://some-other-id",
+	}
+	tests := []struct {
+		name          string
+		extensionType string
+		want          []interface{}
+	}{
+		{
+			name: "flattens all the extensions",
+			want: []interface{}{bundle, plugin},
+		},
+		{
+			name:          "flattens only the extensions of the type",
+			extensionType: "plugin",
+			want:          []interface{}{plugin},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, flattenExtensions(exts, tt.extensionType))
+		})
+	}
+}
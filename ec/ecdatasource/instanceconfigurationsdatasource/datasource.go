@@ -0,0 +1,127 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package instanceconfigurationsdatasource
+
+import (
+	"context"
+	"strconv"
+	"time"
+
+	"github.com/elastic/cloud-sdk-go/pkg/api/platformapi/instanceconfigapi"
+	"github.com/elastic/cloud-sdk-go/pkg/models"
+	"github.com/elastic/cloud-sdk-go/pkg/multierror"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+
+	"github.com/elastic/terraform-provider-ec/ec/util"
+)
+
+// DataSource returns the ec_instance_configurations data source schema.
+func DataSource() *schema.Resource {
+	return &schema.Resource{
+		ReadContext: read,
+
+		Schema: newSchema(),
+
+		Timeouts: &schema.ResourceTimeout{
+			Default: schema.DefaultTimeout(5 * time.Minute),
+		},
+	}
+}
+
+func read(_ context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client := meta.(*util.ProviderMeta).API
+	region := d.Get("region").(string)
+	instanceType := d.Get("instance_type").(string)
+
+	res, err := instanceconfigapi.List(instanceconfigapi.ListParams{
+		API:    client,
+		Region: region,
+	})
+	if err != nil {
+		return diag.FromErr(
+			multierror.NewPrefixed("failed listing instance configurations", err),
+		)
+	}
+
+	if d.Id() == "" {
+		d.SetId(strconv.Itoa(schema.HashString(region + instanceType)))
+	}
+
+	configs := flattenInstanceConfigurations(res, instanceType)
+	if err := d.Set("instance_configurations", configs); err != nil {
+		return diag.FromErr(err)
+	}
+
+	return nil
+}
+
+// flattenInstanceConfigurations flattens the instance configurations, only
+// keeping the ones of the instance type when it's set. Deleted instance
+// configurations are always skipped.
+func flattenInstanceConfigurations(in []*models.InstanceConfiguration, instanceType string) []interface{} {
+	var result = make([]interface{}, 0, len(in))
+	for _, ic := range in {
+		if ic == nil || ic.ID == "" || ic.DeletedOn != nil {
+			continue
+		}
+
+		if instanceType != "" && (ic.InstanceType == nil || *ic.InstanceType != instanceType) {
+			continue
+		}
+
+		var m = map[string]interface{}{
+			"id":                 ic.ID,
+			"description":        ic.Description,
+			"node_types":         util.StringToItems(ic.NodeTypes...),
+			"storage_multiplier": ic.StorageMultiplier,
+		}
+
+		if ic.Name != nil {
+			m["name"] = *ic.Name
+		}
+
+		if ic.InstanceType != nil {
+			m["instance_type"] = *ic.InstanceType
+		}
+
+		if ic.SystemOwned != nil {
+			m["system_owned"] = *ic.SystemOwned
+		}
+
+		if sizes := ic.DiscreteSizes; sizes != nil {
+			if sizes.Resource != nil {
+				m["size_resource"] = *sizes.Resource
+			}
+
+			if sizes.DefaultSize != nil {
+				m["default_size"] = int(*sizes.DefaultSize)
+			}
+
+			var s = make([]interface{}, 0, len(sizes.Sizes))
+			for _, size := range sizes.Sizes {
+				s = append(s, int(size))
+			}
+			m["sizes"] = s
+		}
+
+		result = append(result, m)
+	}
+
+	return result
+}
@@ -0,0 +1,103 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package instanceconfigurationsdatasource
+
+import (
+	"testing"
+
+	"github.com/elastic/cloud-sdk-go/pkg/models"
+	"github.com/elastic/cloud-sdk-go/pkg/util/ec"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_flattenInstanceConfigurations(t *testing.T) {
+	configs := []*models.InstanceConfiguration{
+		{
+			ID:                "data.highstorage",
+			Name:              ec.String("data.highstorage"),
+			Description:       "Instance configuration to be used for a higher disk/memory ratio",
+			InstanceType:      ec.String("elasticsearch"),
+			NodeTypes:         []string{"data", "ingest", "master"},
+			StorageMultiplier: 32,
+			SystemOwned:       ec.Bool(true),
+			DiscreteSizes: &models.DiscreteSizes{
+				Resource:    ec.String("memory"),
+				DefaultSize: ec.Int32(4096),
+				Sizes:       []int32{1024, 2048, 4096},
+			},
+		},
+		{
+			ID:           "kibana",
+			Name:         ec.String("kibana"),
+			InstanceType: ec.String("kibana"),
+			SystemOwned:  ec.Bool(false),
+		},
+	}
+	wantES := map[string]interface{}{
+		"id":                 "data.highstorage",
+		"name":               "data.highstorage",
+		"description":        "Instance configuration to be used for a higher disk/memory ratio",
+		"instance_type":      "elasticsearch",
+		"node_types":         []interface{}{"data", "ingest", "master"},
+		"storage_multiplier": float64(32),
+		"system_owned":       true,
+		"size_resource":      "memory",
+		"default_size":       4096,
+		"sizes":              []interface{}{1024, 2048, 4096},
+	}
+	wantKibana := map[string]interface{}{
+		"id":                 "kibana",
+		"name":               "kibana",
+		"description":        "",
+		"instance_type":      "kibana",
+		"node_types":         []interface{}(nil),
+		"storage_multiplier": float64(0),
+		"system_owned":       false,
+	}
+	type args struct {
+		in           []*models.InstanceConfiguration
+		instanceType string
+	}
+	tests := []struct {
+		name string
+		args args
+		want []interface{}
+	}{
+		{
+			name: "empty instance configurations flatten to an empty list",
+			args: args{},
+			want: []interface{}{},
+		},
+		{
+			name: "flattens all the instance configurations",
+			args: args{in: configs},
+			want: []interface{}{wantES, wantKibana},
+		},
+		{
+			name: "filters the instance configurations by instance type",
+			args: args{in: configs, instanceType: "kibana"},
+			want: []interface{}{wantKibana},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := flattenInstanceConfigurations(tt.args.in, tt.args.instanceType)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
@@ -0,0 +1,100 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package privatelinkdatasource
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+// awsEndpoint is the AWS PrivateLink endpoint of an Elastic Cloud region.
+type awsEndpoint struct {
+	serviceName string
+	domainName  string
+}
+
+// awsEndpoints lists the AWS PrivateLink endpoints by region, as published in
+// the Elastic Cloud PrivateLink documentation. The API doesn't expose them, so
+// the list has to be kept up to date when new regions are added.
+var awsEndpoints = map[string]awsEndpoint{
+	"ap-northeast-1": {"com.amazonaws.vpce.ap-northeast-1.vpce-svc-0e1046d7b48d5cf5f", "vpce.ap-northeast-1.aws.elastic-cloud.com"},
+	"ap-south-1":     {"com.amazonaws.vpce.ap-south-1.vpce-svc-0e9c1ae5caa269d1b", "vpce.ap-south-1.aws.elastic-cloud.com"},
+	"ap-southeast-1": {"com.amazonaws.vpce.ap-southeast-1.vpce-svc-0cbc6cb9bdb683a95", "vpce.ap-southeast-1.aws.elastic-cloud.com"},
+	"ap-southeast-2": {"com.amazonaws.vpce.ap-southeast-2.vpce-svc-0cde7432c1436ef13", "vpce.ap-southeast-2.aws.elastic-cloud.com"},
+	"ca-central-1":   {"com.amazonaws.vpce.ca-central-1.vpce-svc-0d3e69dd6dd336c28", "vpce.ca-central-1.aws.elastic-cloud.com"},
+	"eu-central-1":   {"com.amazonaws.vpce.eu-central-1.vpce-svc-081b2960e915a0861", "vpce.eu-central-1.aws.elastic-cloud.com"},
+	"eu-west-1":      {"com.amazonaws.vpce.eu-west-1.vpce-svc-01f2afe87944eb12b", "vpce.eu-west-1.aws.elastic-cloud.com"},
+	"eu-west-2":      {"com.amazonaws.vpce.eu-west-2.vpce-svc-0e42a2c194c97a1d0", "vpce.eu-west-2.aws.elastic-cloud.com"},
+	"eu-west-3":      {"com.amazonaws.vpce.eu-west-3.vpce-svc-0d6912d10db9693d1", "vpce.eu-west-3.aws.elastic-cloud.com"},
+	"sa-east-1":      {"com.amazonaws.vpce.sa-east-1.vpce-svc-0b2dbce7e04dae763", "vpce.sa-east-1.aws.elastic-cloud.com"},
+	"us-east-1":      {"com.amazonaws.vpce.us-east-1.vpce-svc-0e42e1e06ed010238", "vpce.us-east-1.aws.elastic-cloud.com"},
+	"us-east-2":      {"com.amazonaws.vpce.us-east-2.vpce-svc-02d187d2849ffb478", "vpce.us-east-2.aws.elastic-cloud.com"},
+	"us-west-1":      {"com.amazonaws.vpce.us-west-1.vpce-svc-00def4a16a26cb1b4", "vpce.us-west-1.aws.elastic-cloud.com"},
+	"us-west-2":      {"com.amazonaws.vpce.us-west-2.vpce-svc-0e69febae1fb91870", "vpce.us-west-2.aws.elastic-cloud.com"},
+}
+
+// AwsDataSource returns the ec_aws_privatelink_endpoint data source schema.
+func AwsDataSource() *schema.Resource {
+	return &schema.Resource{
+		ReadContext: readAws,
+
+		Schema: map[string]*schema.Schema{
+			"region": {
+				Type:     schema.TypeString,
+				Required: true,
+			},
+
+			// Computed
+			"vpc_service_name": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"domain_name": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+		},
+	}
+}
+
+func readAws(_ context.Context, d *schema.ResourceData, _ interface{}) diag.Diagnostics {
+	region := d.Get("region").(string)
+	endpoint, ok := awsEndpoints[region]
+	if !ok {
+		return diag.FromErr(unsupportedRegionError("AWS PrivateLink", region))
+	}
+
+	d.SetId(region)
+
+	if err := d.Set("vpc_service_name", endpoint.serviceName); err != nil {
+		return diag.FromErr(err)
+	}
+
+	if err := d.Set("domain_name", endpoint.domainName); err != nil {
+		return diag.FromErr(err)
+	}
+
+	return nil
+}
+
+func unsupportedRegionError(kind, region string) error {
+	return fmt.Errorf(`%s isn't available in the "%s" region`, kind, region)
+}
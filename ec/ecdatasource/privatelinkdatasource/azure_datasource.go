@@ -0,0 +1,85 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package privatelinkdatasource
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+// azureServiceAliases lists the Azure Private Link service aliases by region,
+// as published in the Elastic Cloud Private Link documentation. The API doesn't
+// expose them, so the list has to be kept up to date when new regions are
+// added. The private DNS domain of each region follows the same naming scheme.
+var azureServiceAliases = map[string]string{
+	"eastus2":    "eastus2-prod-002-privatelink-service.64359670-7d6a-4e6e-bd5e-6bff0ee6c2f3.eastus2.azure.privatelinkservice",
+	"uksouth":    "uksouth-prod-007-privatelink-service.98758729-06f7-438d-baaa-0cb63e737cdf.uksouth.azure.privatelinkservice",
+	"westeurope": "westeurope-prod-001-privatelink-service.190cd496-6d79-4ee2-8f23-0667fd5a8ec1.westeurope.azure.privatelinkservice",
+}
+
+// AzureDataSource returns the ec_azure_privatelink_endpoint data source schema.
+func AzureDataSource() *schema.Resource {
+	return &schema.Resource{
+		ReadContext: readAzure,
+
+		Schema: map[string]*schema.Schema{
+			"region": {
+				Type:     schema.TypeString,
+				Required: true,
+			},
+
+			// Computed
+			"service_alias": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"domain_name": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+		},
+	}
+}
+
+func readAzure(_ context.Context, d *schema.ResourceData, _ interface{}) diag.Diagnostics {
+	region := d.Get("region").(string)
+
+	// Elastic Cloud Azure regions are the Azure regions with an "azure-" prefix.
+	azureRegion := strings.TrimPrefix(region, "azure-")
+	alias, ok := azureServiceAliases[azureRegion]
+	if !ok {
+		return diag.FromErr(unsupportedRegionError("Azure Private Link", region))
+	}
+
+	d.SetId(region)
+
+	if err := d.Set("service_alias", alias); err != nil {
+		return diag.FromErr(err)
+	}
+
+	domain := fmt.Sprintf("privatelink.%s.azure.elastic-cloud.com", azureRegion)
+	if err := d.Set("domain_name", domain); err != nil {
+		return diag.FromErr(err)
+	}
+
+	return nil
+}
@@ -0,0 +1,78 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package privatelinkdatasource
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_readAzure(t *testing.T) {
+	tests := []struct {
+		name   string
+		region string
+		want   map[string]string
+		diags  diag.Diagnostics
+	}{
+		{
+			name:   "returns the endpoint of a supported region",
+			region: "azure-eastus2",
+			want: map[string]string{
+				"id":            "azure-eastus2",
+				"region":        "azure-eastus2",
+				"service_alias": "eastus2-prod-002-privatelink-service.64359670-7d6a-4e6e-bd5e-6bff0ee6c2f3.eastus2.azure.privatelinkservice",
+				"domain_name":   "privatelink.eastus2.azure.elastic-cloud.com",
+			},
+		},
+		{
+			name:   "accepts the Azure region name",
+			region: "westeurope",
+			want: map[string]string{
+				"id":            "westeurope",
+				"region":        "westeurope",
+				"service_alias": "westeurope-prod-001-privatelink-service.190cd496-6d79-4ee2-8f23-0667fd5a8ec1.westeurope.azure.privatelinkservice",
+				"domain_name":   "privatelink.westeurope.azure.elastic-cloud.com",
+			},
+		},
+		{
+			name:   "fails on an unsupported region",
+			region: "us-east-1",
+			diags: diag.FromErr(
+				unsupportedRegionError("Azure Private Link", "us-east-1"),
+			),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			d := schema.TestResourceDataRaw(t, AzureDataSource().Schema, map[string]interface{}{
+				"region": tt.region,
+			})
+
+			diags := readAzure(context.Background(), d, nil)
+			assert.Equal(t, tt.diags, diags)
+
+			if len(tt.want) > 0 {
+				assert.Equal(t, tt.want, d.State().Attributes)
+			}
+		})
+	}
+}
@@ -0,0 +1,101 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package privatelinkdatasource
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+// gcpRegions lists the GCP regions which offer Private Service Connect, as
+// published in the Elastic Cloud documentation. The service attachment and the
+// DNS domain of each region follow the same naming scheme.
+var gcpRegions = map[string]bool{
+	"asia-east1":              true,
+	"asia-northeast1":         true,
+	"asia-northeast3":         true,
+	"asia-south1":             true,
+	"asia-southeast1":         true,
+	"australia-southeast1":    true,
+	"europe-north1":           true,
+	"europe-west1":            true,
+	"europe-west2":            true,
+	"europe-west3":            true,
+	"europe-west4":            true,
+	"northamerica-northeast1": true,
+	"southamerica-east1":      true,
+	"us-central1":             true,
+	"us-east1":                true,
+	"us-east4":                true,
+	"us-west1":                true,
+}
+
+// GcpDataSource returns the ec_gcp_private_service_connect_endpoint data
+// source schema.
+func GcpDataSource() *schema.Resource {
+	return &schema.Resource{
+		ReadContext: readGcp,
+
+		Schema: map[string]*schema.Schema{
+			"region": {
+				Type:     schema.TypeString,
+				Required: true,
+			},
+
+			// Computed
+			"service_attachment_uri": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"domain_name": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+		},
+	}
+}
+
+func readGcp(_ context.Context, d *schema.ResourceData, _ interface{}) diag.Diagnostics {
+	region := d.Get("region").(string)
+
+	// Elastic Cloud GCP regions are the GCP regions with a "gcp-" prefix.
+	gcpRegion := strings.TrimPrefix(region, "gcp-")
+	if !gcpRegions[gcpRegion] {
+		return diag.FromErr(unsupportedRegionError("GCP Private Service Connect", region))
+	}
+
+	d.SetId(region)
+
+	attachment := fmt.Sprintf(
+		"projects/cloud-production-168820/regions/%s/serviceAttachments/proxy-psc-production-%s-v1-attachment",
+		gcpRegion, gcpRegion,
+	)
+	if err := d.Set("service_attachment_uri", attachment); err != nil {
+		return diag.FromErr(err)
+	}
+
+	if err := d.Set("domain_name", fmt.Sprintf("psc.%s.gcp.cloud.es.io", gcpRegion)); err != nil {
+		return diag.FromErr(err)
+	}
+
+	return nil
+}
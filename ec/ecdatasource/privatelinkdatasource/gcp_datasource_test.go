@@ -0,0 +1,78 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package privatelinkdatasource
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_readGcp(t *testing.T) {
+	tests := []struct {
+		name   string
+		region string
+		want   map[string]string
+		diags  diag.Diagnostics
+	}{
+		{
+			name:   "returns the endpoint of a supported region",
+			region: "gcp-us-central1",
+			want: map[string]string{
+				"id":                     "gcp-us-central1",
+				"region":                 "gcp-us-central1",
+				"service_attachment_uri": "projects/cloud-production-168820/regions/us-central1/serviceAttachments/proxy-psc-production-us-central1-v1-attachment",
+				"domain_name":            "psc.us-central1.gcp.cloud.es.io",
+			},
+		},
+		{
+			name:   "accepts the GCP region name",
+			region: "europe-west1",
+			want: map[string]string{
+				"id":                     "europe-west1",
+				"region":                 "europe-west1",
+				"service_attachment_uri": "projects/cloud-production-168820/regions/europe-west1/serviceAttachments/proxy-psc-production-europe-west1-v1-attachment",
+				"domain_name":            "psc.europe-west1.gcp.cloud.es.io",
+			},
+		},
+		{
+			name:   "fails on an unsupported region",
+			region: "us-east-1",
+			diags: diag.FromErr(
+				unsupportedRegionError("GCP Private Service Connect", "us-east-1"),
+			),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			d := schema.TestResourceDataRaw(t, GcpDataSource().Schema, map[string]interface{}{
+				"region": tt.region,
+			})
+
+			diags := readGcp(context.Background(), d, nil)
+			assert.Equal(t, tt.diags, diags)
+
+			if len(tt.want) > 0 {
+				assert.Equal(t, tt.want, d.State().Attributes)
+			}
+		})
+	}
+}
@@ -24,14 +24,13 @@ import (
 	"strconv"
 	"time"
 
-	"github.com/elastic/cloud-sdk-go/pkg/api"
-	"github.com/elastic/cloud-sdk-go/pkg/api/stackapi"
 	"github.com/elastic/cloud-sdk-go/pkg/models"
 	"github.com/elastic/cloud-sdk-go/pkg/multierror"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 
 	"github.com/elastic/terraform-provider-ec/ec/ecdatasource/stackdatasource/state"
+	"github.com/elastic/terraform-provider-ec/ec/util"
 )
 
 // DataSource returns the ec_deployment data source schema.
@@ -48,13 +47,10 @@ func DataSource() *schema.Resource {
 }
 
 func read(_ context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	client := meta.(*api.API)
+	providerMeta := meta.(*util.ProviderMeta)
 	region := d.Get("region").(string)
 
-	res, err := stackapi.List(stackapi.ListParams{
-		API:    client,
-		Region: region,
-	})
+	res, err := providerMeta.ListStackVersions(region)
 	if err != nil {
 		return diag.FromErr(
 			multierror.NewPrefixed("failed retrieving the specified stack version", err),
@@ -0,0 +1,112 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package stackversionsdatasource
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"strconv"
+	"time"
+
+	"github.com/elastic/cloud-sdk-go/pkg/models"
+	"github.com/elastic/cloud-sdk-go/pkg/multierror"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+
+	"github.com/elastic/terraform-provider-ec/ec/util"
+)
+
+// DataSource returns the ec_stack_versions data source schema.
+func DataSource() *schema.Resource {
+	return &schema.Resource{
+		ReadContext: read,
+
+		Schema: newSchema(),
+
+		Timeouts: &schema.ResourceTimeout{
+			Default: schema.DefaultTimeout(5 * time.Minute),
+		},
+	}
+}
+
+func read(_ context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	providerMeta := meta.(*util.ProviderMeta)
+	region := d.Get("region").(string)
+
+	res, err := providerMeta.ListStackVersions(region)
+	if err != nil {
+		return diag.FromErr(
+			multierror.NewPrefixed("failed retrieving the stack versions", err),
+		)
+	}
+
+	versionExpr := d.Get("version_regex").(string)
+	versions, err := flattenVersions(versionExpr, res.Stacks)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	if d.Id() == "" {
+		d.SetId(strconv.Itoa(schema.HashString(region + versionExpr)))
+	}
+
+	if err := d.Set("versions", versions); err != nil {
+		return diag.FromErr(err)
+	}
+
+	return nil
+}
+
+// flattenVersions flattens the stack versions matching the expression, or all
+// of them when it's empty, keeping the order returned by the API, which lists
+// the latest versions first.
+func flattenVersions(expr string, stacks []*models.StackVersionConfig) ([]interface{}, error) {
+	var re *regexp.Regexp
+	if expr != "" {
+		var err error
+		if re, err = regexp.Compile(expr); err != nil {
+			return nil, fmt.Errorf("failed to compile the version_regex: %w", err)
+		}
+	}
+
+	var result = make([]interface{}, 0, len(stacks))
+	for _, stack := range stacks {
+		if stack == nil || (re != nil && !re.MatchString(stack.Version)) {
+			continue
+		}
+
+		var m = map[string]interface{}{
+			"version":             stack.Version,
+			"min_upgradable_from": stack.MinUpgradableFrom,
+			"upgradable_to":       util.StringToItems(stack.UpgradableTo...),
+		}
+
+		if stack.Accessible != nil {
+			m["accessible"] = *stack.Accessible
+		}
+
+		if stack.Whitelisted != nil {
+			m["allowlisted"] = *stack.Whitelisted
+		}
+
+		result = append(result, m)
+	}
+
+	return result, nil
+}
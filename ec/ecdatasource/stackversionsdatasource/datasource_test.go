@@ -0,0 +1,135 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package stackversionsdatasource
+
+import (
+	"errors"
+	"regexp/syntax"
+	"testing"
+
+	"github.com/elastic/cloud-sdk-go/pkg/models"
+	"github.com/elastic/cloud-sdk-go/pkg/util/ec"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_flattenVersions(t *testing.T) {
+	stacks := []*models.StackVersionConfig{
+		{
+			Version:           "7.9.1",
+			Accessible:        ec.Bool(true),
+			Whitelisted:       ec.Bool(true),
+			MinUpgradableFrom: "6.8.0",
+			UpgradableTo:      []string{"7.10.0"},
+		},
+		{
+			Version:           "7.9.0",
+			Accessible:        ec.Bool(true),
+			Whitelisted:       ec.Bool(false),
+			MinUpgradableFrom: "6.8.0",
+			UpgradableTo:      []string{"7.9.1", "7.10.0"},
+		},
+		{
+			Version:           "6.8.12",
+			Accessible:        ec.Bool(true),
+			Whitelisted:       ec.Bool(true),
+			MinUpgradableFrom: "5.6.0",
+			UpgradableTo:      []string{"7.9.0", "7.9.1"},
+		},
+	}
+	type args struct {
+		expr   string
+		stacks []*models.StackVersionConfig
+	}
+	tests := []struct {
+		name string
+		args args
+		want []interface{}
+		err  error
+	}{
+		{
+			name: "empty stacks flatten to an empty list",
+			args: args{},
+			want: []interface{}{},
+		},
+		{
+			name: "no expression returns all the versions",
+			args: args{stacks: stacks},
+			want: []interface{}{
+				map[string]interface{}{
+					"version":             "7.9.1",
+					"accessible":          true,
+					"allowlisted":         true,
+					"min_upgradable_from": "6.8.0",
+					"upgradable_to":       []interface{}{"7.10.0"},
+				},
+				map[string]interface{}{
+					"version":             "7.9.0",
+					"accessible":          true,
+					"allowlisted":         false,
+					"min_upgradable_from": "6.8.0",
+					"upgradable_to":       []interface{}{"7.9.1", "7.10.0"},
+				},
+				map[string]interface{}{
+					"version":             "6.8.12",
+					"accessible":          true,
+					"allowlisted":         true,
+					"min_upgradable_from": "5.6.0",
+					"upgradable_to":       []interface{}{"7.9.0", "7.9.1"},
+				},
+			},
+		},
+		{
+			name: "expression filters the versions",
+			args: args{expr: "^7\\.9\\..*$", stacks: stacks},
+			want: []interface{}{
+				map[string]interface{}{
+					"version":             "7.9.1",
+					"accessible":          true,
+					"allowlisted":         true,
+					"min_upgradable_from": "6.8.0",
+					"upgradable_to":       []interface{}{"7.10.0"},
+				},
+				map[string]interface{}{
+					"version":             "7.9.0",
+					"accessible":          true,
+					"allowlisted":         false,
+					"min_upgradable_from": "6.8.0",
+					"upgradable_to":       []interface{}{"7.9.1", "7.10.0"},
+				},
+			},
+		},
+		{
+			name: "invalid expression returns an error",
+			args: args{expr: "(", stacks: stacks},
+			err: errors.New("failed to compile the version_regex: " + (&syntax.Error{
+				Code: syntax.ErrMissingParen, Expr: "(",
+			}).Error()),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := flattenVersions(tt.args.expr, tt.args.stacks)
+			if tt.err != nil {
+				assert.EqualError(t, err, tt.err.Error())
+			} else {
+				assert.NoError(t, err)
+			}
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
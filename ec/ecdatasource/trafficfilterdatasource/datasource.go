@@ -0,0 +1,174 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package trafficfilterdatasource
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/elastic/cloud-sdk-go/pkg/api/deploymentapi/trafficfilterapi"
+	"github.com/elastic/cloud-sdk-go/pkg/models"
+	"github.com/elastic/cloud-sdk-go/pkg/multierror"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+
+	"github.com/elastic/terraform-provider-ec/ec/util"
+)
+
+// DataSource returns the ec_traffic_filter data source schema.
+func DataSource() *schema.Resource {
+	return &schema.Resource{
+		ReadContext: read,
+
+		Schema: newSchema(),
+
+		Timeouts: &schema.ResourceTimeout{
+			Default: schema.DefaultTimeout(5 * time.Minute),
+		},
+	}
+}
+
+func read(_ context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client := meta.(*util.ProviderMeta).API
+
+	res, err := trafficfilterapi.List(trafficfilterapi.ListParams{
+		API:    client,
+		Region: d.Get("region").(string),
+	})
+	if err != nil {
+		return diag.FromErr(
+			multierror.NewPrefixed("failed listing traffic filter rulesets", err),
+		)
+	}
+
+	ruleset, err := rulesetFromFilters(
+		d.Get("ruleset_id").(string), d.Get("name").(string), res.Rulesets,
+	)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	d.SetId(*ruleset.ID)
+
+	if err := modelToState(d, ruleset); err != nil {
+		return diag.FromErr(err)
+	}
+
+	return nil
+}
+
+// rulesetFromFilters returns the ruleset matching the ID or, when no ID is
+// set, the name. Since ruleset names aren't unique, an error is returned when
+// more than one ruleset has the name.
+func rulesetFromFilters(id, name string, rulesets []*models.TrafficFilterRulesetInfo) (*models.TrafficFilterRulesetInfo, error) {
+	var matches []*models.TrafficFilterRulesetInfo
+	for _, ruleset := range rulesets {
+		if ruleset == nil || ruleset.ID == nil {
+			continue
+		}
+
+		if id != "" && *ruleset.ID == id {
+			return ruleset, nil
+		}
+
+		if id == "" && ruleset.Name != nil && *ruleset.Name == name {
+			matches = append(matches, ruleset)
+		}
+	}
+
+	if id != "" {
+		return nil, fmt.Errorf(`failed to obtain a traffic filter ruleset with id "%s"`, id)
+	}
+
+	switch len(matches) {
+	case 0:
+		return nil, fmt.Errorf(`failed to obtain a traffic filter ruleset named "%s"`, name)
+	case 1:
+		return matches[0], nil
+	}
+
+	var ids = make([]string, 0, len(matches))
+	for _, ruleset := range matches {
+		ids = append(ids, *ruleset.ID)
+	}
+
+	return nil, fmt.Errorf(
+		`found %d traffic filter rulesets named "%s" (%s): please specify the ruleset_id or region`,
+		len(matches), name, strings.Join(ids, ", "),
+	)
+}
+
+func modelToState(d *schema.ResourceData, ruleset *models.TrafficFilterRulesetInfo) error {
+	if err := d.Set("ruleset_id", *ruleset.ID); err != nil {
+		return err
+	}
+
+	if ruleset.Name != nil {
+		if err := d.Set("name", *ruleset.Name); err != nil {
+			return err
+		}
+	}
+
+	if ruleset.Type != nil {
+		if err := d.Set("type", *ruleset.Type); err != nil {
+			return err
+		}
+	}
+
+	if ruleset.Region != nil {
+		if err := d.Set("region", *ruleset.Region); err != nil {
+			return err
+		}
+	}
+
+	if err := d.Set("description", ruleset.Description); err != nil {
+		return err
+	}
+
+	if ruleset.IncludeByDefault != nil {
+		if err := d.Set("include_by_default", *ruleset.IncludeByDefault); err != nil {
+			return err
+		}
+	}
+
+	return d.Set("rule", flattenRules(ruleset.Rules))
+}
+
+func flattenRules(rules []*models.TrafficFilterRule) []interface{} {
+	var result = make([]interface{}, 0, len(rules))
+	for _, rule := range rules {
+		if rule == nil {
+			continue
+		}
+
+		var m = map[string]interface{}{
+			"id":          rule.ID,
+			"description": rule.Description,
+		}
+
+		if rule.Source != nil {
+			m["source"] = *rule.Source
+		}
+
+		result = append(result, m)
+	}
+
+	return result
+}
@@ -0,0 +1,164 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package trafficfilterdatasource
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/elastic/cloud-sdk-go/pkg/models"
+	"github.com/elastic/cloud-sdk-go/pkg/util/ec"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_modelToState(t *testing.T) {
+	rulesetSchemaArg := schema.TestResourceDataRaw(t, newSchema(), nil)
+	rulesetSchemaArg.SetId("some-id")
+	_ = rulesetSchemaArg.Set("name", "my ruleset")
+
+	wantRuleset := newResourceData(t, resDataParams{
+		ID: "some-id",
+		Resources: map[string]interface{}{
+			"ruleset_id":         "some-id",
+			"name":               "my ruleset",
+			"type":               "ip",
+			"region":             "us-east-1",
+			"description":        "company wide allowlist",
+			"include_by_default": true,
+			"rule": []interface{}{
+				map[string]interface{}{
+					"id":          "some-rule-id",
+					"source":      "0.0.0.0/0",
+					"description": "all",
+				},
+			},
+		},
+	})
+
+	type args struct {
+		d   *schema.ResourceData
+		res *models.TrafficFilterRulesetInfo
+	}
+	tests := []struct {
+		name string
+		args args
+		want *schema.ResourceData
+		err  error
+	}{
+		{
+			name: "flattens the traffic filter ruleset",
+			want: wantRuleset,
+			args: args{
+				d: rulesetSchemaArg,
+				res: &models.TrafficFilterRulesetInfo{
+					ID:               ec.String("some-id"),
+					Name:             ec.String("my ruleset"),
+					Type:             ec.String("ip"),
+					Region:           ec.String("us-east-1"),
+					Description:      "company wide allowlist",
+					IncludeByDefault: ec.Bool(true),
+					Rules: []*models.TrafficFilterRule{
+						{ID: "some-rule-id", Source: ec.String("0.0.0.0/0"), Description: "all"},
+					},
+				},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := modelToState(tt.args.d, tt.args.res)
+			if tt.err != nil {
+				assert.EqualError(t, err, tt.err.Error())
+			} else {
+				assert.NoError(t, err)
+			}
+
+			assert.Equal(t, tt.want.State().Attributes, tt.args.d.State().Attributes)
+		})
+	}
+}
+
+type resDataParams struct {
+	Resources map[string]interface{}
+	ID        string
+}
+
+func newResourceData(t *testing.T, params resDataParams) *schema.ResourceData {
+	raw := schema.TestResourceDataRaw(t, DataSource().Schema, params.Resources)
+	raw.SetId(params.ID)
+
+	return raw
+}
+
+func Test_rulesetFromFilters(t *testing.T) {
+	var rulesets = []*models.TrafficFilterRulesetInfo{
+		{ID: ec.String("some-id"), Name: ec.String("office")},
+		{ID: ec.String("some-other-id"), Name: ec.String("vpn")},
+		{ID: ec.String("another-id"), Name: ec.String("vpn")},
+	}
+	type args struct {
+		id       string
+		name     string
+		rulesets []*models.TrafficFilterRulesetInfo
+	}
+	tests := []struct {
+		name string
+		args args
+		want *models.TrafficFilterRulesetInfo
+		err  error
+	}{
+		{
+			name: "returns the ruleset matching the id",
+			args: args{id: "another-id", rulesets: rulesets},
+			want: rulesets[2],
+		},
+		{
+			name: "returns the ruleset matching the name",
+			args: args{name: "office", rulesets: rulesets},
+			want: rulesets[0],
+		},
+		{
+			name: "returns an error when no ruleset has the id",
+			args: args{id: "unknown", rulesets: rulesets},
+			err:  errors.New(`failed to obtain a traffic filter ruleset with id "unknown"`),
+		},
+		{
+			name: "returns an error when no ruleset has the name",
+			args: args{name: "unknown", rulesets: rulesets},
+			err:  errors.New(`failed to obtain a traffic filter ruleset named "unknown"`),
+		},
+		{
+			name: "returns an error when more than one ruleset has the name",
+			args: args{name: "vpn", rulesets: rulesets},
+			err:  errors.New(`found 2 traffic filter rulesets named "vpn" (some-other-id, another-id): please specify the ruleset_id or region`),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := rulesetFromFilters(tt.args.id, tt.args.name, tt.args.rulesets)
+			if tt.err != nil {
+				assert.EqualError(t, err, tt.err.Error())
+			} else {
+				assert.NoError(t, err)
+			}
+
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
@@ -0,0 +1,138 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package trafficfiltersdatasource
+
+import (
+	"context"
+	"strconv"
+	"time"
+
+	"github.com/elastic/cloud-sdk-go/pkg/api/deploymentapi/trafficfilterapi"
+	"github.com/elastic/cloud-sdk-go/pkg/models"
+	"github.com/elastic/cloud-sdk-go/pkg/multierror"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+
+	"github.com/elastic/terraform-provider-ec/ec/util"
+)
+
+// DataSource returns the ec_traffic_filters data source schema.
+func DataSource() *schema.Resource {
+	return &schema.Resource{
+		ReadContext: read,
+
+		Schema: newSchema(),
+
+		Timeouts: &schema.ResourceTimeout{
+			Default: schema.DefaultTimeout(5 * time.Minute),
+		},
+	}
+}
+
+func read(_ context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client := meta.(*util.ProviderMeta).API
+	region := d.Get("region").(string)
+
+	res, err := trafficfilterapi.List(trafficfilterapi.ListParams{
+		API:    client,
+		Region: region,
+	})
+	if err != nil {
+		return diag.FromErr(
+			multierror.NewPrefixed("failed listing traffic filter rulesets", err),
+		)
+	}
+
+	if d.Id() == "" {
+		d.SetId(strconv.Itoa(schema.HashString(region)))
+	}
+
+	if err := modelToState(d, res); err != nil {
+		return diag.FromErr(err)
+	}
+
+	return nil
+}
+
+func modelToState(d *schema.ResourceData, res *models.TrafficFilterRulesets) error {
+	if res == nil {
+		return nil
+	}
+
+	return d.Set("rulesets", flattenRulesets(res.Rulesets))
+}
+
+func flattenRulesets(rulesets []*models.TrafficFilterRulesetInfo) []interface{} {
+	var result = make([]interface{}, 0, len(rulesets))
+	for _, ruleset := range rulesets {
+		if ruleset == nil {
+			continue
+		}
+
+		var m = map[string]interface{}{
+			"description": ruleset.Description,
+			"rule":        flattenRules(ruleset.Rules),
+		}
+
+		if ruleset.ID != nil {
+			m["id"] = *ruleset.ID
+		}
+
+		if ruleset.Name != nil {
+			m["name"] = *ruleset.Name
+		}
+
+		if ruleset.Type != nil {
+			m["type"] = *ruleset.Type
+		}
+
+		if ruleset.Region != nil {
+			m["region"] = *ruleset.Region
+		}
+
+		if ruleset.IncludeByDefault != nil {
+			m["include_by_default"] = *ruleset.IncludeByDefault
+		}
+
+		result = append(result, m)
+	}
+
+	return result
+}
+
+func flattenRules(rules []*models.TrafficFilterRule) []interface{} {
+	var result = make([]interface{}, 0, len(rules))
+	for _, rule := range rules {
+		if rule == nil {
+			continue
+		}
+
+		var m = map[string]interface{}{
+			"id":          rule.ID,
+			"description": rule.Description,
+		}
+
+		if rule.Source != nil {
+			m["source"] = *rule.Source
+		}
+
+		result = append(result, m)
+	}
+
+	return result
+}
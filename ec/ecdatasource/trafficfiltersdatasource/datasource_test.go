@@ -0,0 +1,144 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package trafficfiltersdatasource
+
+import (
+	"testing"
+
+	"github.com/elastic/cloud-sdk-go/pkg/models"
+	"github.com/elastic/cloud-sdk-go/pkg/util/ec"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_modelToState(t *testing.T) {
+	rulesetsSchemaArg := schema.TestResourceDataRaw(t, newSchema(), nil)
+	rulesetsSchemaArg.SetId("someid")
+	_ = rulesetsSchemaArg.Set("region", "us-east-1")
+
+	wantRulesets := newResourceData(t, resDataParams{
+		ID: "someid",
+		Resources: map[string]interface{}{
+			"region": "us-east-1",
+			"rulesets": []interface{}{
+				map[string]interface{}{
+					"id":                 "some-id",
+					"name":               "my ruleset",
+					"type":               "ip",
+					"region":             "us-east-1",
+					"description":        "company wide allowlist",
+					"include_by_default": true,
+					"rule": []interface{}{
+						map[string]interface{}{
+							"id":          "some-rule-id",
+							"source":      "0.0.0.0/0",
+							"description": "all",
+						},
+						map[string]interface{}{
+							"id":          "some-other-rule-id",
+							"source":      "1.1.1.0/24",
+							"description": "",
+						},
+					},
+				},
+				map[string]interface{}{
+					"id":                 "some-other-id",
+					"name":               "my vpce ruleset",
+					"type":               "vpce",
+					"region":             "us-east-1",
+					"description":        "",
+					"include_by_default": false,
+					"rule": []interface{}{
+						map[string]interface{}{
+							"id":          "some-vpce-rule-id",
+							"source":      "vpce-1234",
+							"description": "",
+						},
+					},
+				},
+			},
+		},
+	})
+
+	type args struct {
+		d   *schema.ResourceData
+		res *models.TrafficFilterRulesets
+	}
+	tests := []struct {
+		name string
+		args args
+		want *schema.ResourceData
+		err  error
+	}{
+		{
+			name: "flattens the traffic filter rulesets",
+			want: wantRulesets,
+			args: args{
+				d: rulesetsSchemaArg,
+				res: &models.TrafficFilterRulesets{Rulesets: []*models.TrafficFilterRulesetInfo{
+					{
+						ID:               ec.String("some-id"),
+						Name:             ec.String("my ruleset"),
+						Type:             ec.String("ip"),
+						Region:           ec.String("us-east-1"),
+						Description:      "company wide allowlist",
+						IncludeByDefault: ec.Bool(true),
+						Rules: []*models.TrafficFilterRule{
+							{ID: "some-rule-id", Source: ec.String("0.0.0.0/0"), Description: "all"},
+							{ID: "some-other-rule-id", Source: ec.String("1.1.1.0/24")},
+						},
+					},
+					{
+						ID:               ec.String("some-other-id"),
+						Name:             ec.String("my vpce ruleset"),
+						Type:             ec.String("vpce"),
+						Region:           ec.String("us-east-1"),
+						IncludeByDefault: ec.Bool(false),
+						Rules: []*models.TrafficFilterRule{
+							{ID: "some-vpce-rule-id", Source: ec.String("vpce-1234")},
+						},
+					},
+				}},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := modelToState(tt.args.d, tt.args.res)
+			if tt.err != nil {
+				assert.EqualError(t, err, tt.err.Error())
+			} else {
+				assert.NoError(t, err)
+			}
+
+			assert.Equal(t, tt.want.State().Attributes, tt.args.d.State().Attributes)
+		})
+	}
+}
+
+type resDataParams struct {
+	Resources map[string]interface{}
+	ID        string
+}
+
+func newResourceData(t *testing.T, params resDataParams) *schema.ResourceData {
+	raw := schema.TestResourceDataRaw(t, DataSource().Schema, params.Resources)
+	raw.SetId(params.ID)
+
+	return raw
+}
@@ -18,9 +18,11 @@
 package apmstate
 
 import (
+	"fmt"
 	"reflect"
 
 	"github.com/elastic/cloud-sdk-go/pkg/models"
+	"github.com/elastic/cloud-sdk-go/pkg/multierror"
 	"github.com/elastic/cloud-sdk-go/pkg/util/ec"
 
 	"github.com/elastic/terraform-provider-ec/ec/util"
@@ -37,14 +39,20 @@ func ExpandResources(apms []interface{}) ([]*models.ApmPayload, error) {
 	}
 
 	result := make([]*models.ApmPayload, 0, len(apms))
+	var merr = multierror.NewPrefixed("invalid apm resource")
 	for _, raw := range apms {
 		resResource, err := expandResource(raw)
 		if err != nil {
-			return nil, err
+			merr = merr.Append(err)
+			continue
 		}
 		result = append(result, resResource)
 	}
 
+	if err := merr.ErrorOrNil(); err != nil {
+		return nil, err
+	}
+
 	return result, nil
 }
 
@@ -97,12 +105,14 @@ func expandResource(raw interface{}) (*models.ApmPayload, error) {
 func expandTopology(raw interface{}) ([]*models.ApmTopologyElement, error) {
 	var rawTopologies = raw.([]interface{})
 	var res = make([]*models.ApmTopologyElement, 0, len(rawTopologies))
-	for _, rawTop := range rawTopologies {
+	var merr = multierror.NewPrefixed("invalid apm resource")
+	for i, rawTop := range rawTopologies {
 		var topology = rawTop.(map[string]interface{})
 
 		size, err := util.ParseTopologySize(topology)
 		if err != nil {
-			return nil, err
+			merr = merr.Append(fmt.Errorf("topology.%d: %w", i, err))
+			continue
 		}
 
 		var elem = models.ApmTopologyElement{
@@ -124,6 +134,10 @@ func expandTopology(raw interface{}) ([]*models.ApmTopologyElement, error) {
 		res = append(res, &elem)
 	}
 
+	if err := merr.ErrorOrNil(); err != nil {
+		return nil, err
+	}
+
 	return res, nil
 }
 
@@ -105,6 +105,7 @@ func TestFlattenResource(t *testing.T) {
 						map[string]interface{}{
 							"instance_configuration_id": "aws.apm.r4",
 							"memory_per_node":           "1g",
+							"size_resource":             "memory",
 							"zone_count":                int32(1),
 						},
 					},
@@ -165,6 +166,7 @@ func TestFlattenResource(t *testing.T) {
 				"topology": []interface{}{map[string]interface{}{
 					"instance_configuration_id": "aws.apm.r4",
 					"memory_per_node":           "1g",
+					"size_resource":             "memory",
 					"zone_count":                int32(1),
 				}},
 				"config": []interface{}{map[string]interface{}{
@@ -231,6 +233,7 @@ func TestFlattenResource(t *testing.T) {
 				"topology": []interface{}{map[string]interface{}{
 					"instance_configuration_id": "aws.apm.r4",
 					"memory_per_node":           "1g",
+					"size_resource":             "memory",
 					"zone_count":                int32(1),
 				}},
 				"config": []interface{}{map[string]interface{}{
@@ -307,6 +310,7 @@ func TestFlattenResource(t *testing.T) {
 				"topology": []interface{}{map[string]interface{}{
 					"instance_configuration_id": "aws.apm.r4",
 					"memory_per_node":           "1g",
+					"size_resource":             "memory",
 					"zone_count":                int32(1),
 					"config": []interface{}{map[string]interface{}{
 						"user_settings_yaml":          "some.setting: value",
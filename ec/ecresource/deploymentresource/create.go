@@ -19,55 +19,135 @@ package deploymentresource
 
 import (
 	"context"
+	"errors"
 	"fmt"
 
-	"github.com/elastic/cloud-sdk-go/pkg/api"
 	"github.com/elastic/cloud-sdk-go/pkg/api/deploymentapi"
+	"github.com/elastic/cloud-sdk-go/pkg/models"
 	"github.com/elastic/cloud-sdk-go/pkg/multierror"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+
+	"github.com/elastic/terraform-provider-ec/ec/util"
 )
 
 // create will create a new deployment from the specified settings.
 func create(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	client := meta.(*api.API)
+	providerMeta := meta.(*util.ProviderMeta)
+	client := providerMeta.API
 	reqID := deploymentapi.RequestID(d.Get("request_id").(string))
+	defer forgetTrafficFilters(d, providerMeta)
 
-	req, err := createResourceToModel(d)
+	req, err := ExpandCreateRequest(d)
 	if err != nil {
-		diag.FromErr(err)
+		return diag.FromErr(err)
+	}
+
+	if err := ApplyTemplateDefaults(client, d, req.Resources); err != nil {
+		return diag.FromErr(err)
 	}
 
-	res, err := deploymentapi.Create(deploymentapi.CreateParams{
-		API:       client,
-		RequestID: reqID,
-		Request:   req,
-		Overrides: &deploymentapi.PayloadOverrides{
-			Name:    d.Get("name").(string),
-			Version: d.Get("version").(string),
-			Region:  d.Get("region").(string),
-		},
+	dumpPayload("create", reqID, req)
+
+	// The same request ID is sent on every attempt, so the API won't create
+	// a second deployment when a timed out request had already been processed.
+	var res *models.DeploymentCreateResponse
+	err = withCapacityRetry(ctx, providerMeta.Features.Deployment.RetryInsufficientCapacity, func() error {
+		return withTransientRetry(ctx, func() (err error) {
+			res, err = deploymentapi.Create(deploymentapi.CreateParams{
+				API:       client,
+				RequestID: reqID,
+				Request:   req,
+				Overrides: &deploymentapi.PayloadOverrides{
+					Name:    d.Get("name").(string),
+					Version: d.Get("version").(string),
+					Region:  d.Get("region").(string),
+				},
+			})
+			return err
+		})
 	})
 	if err != nil {
 		merr := multierror.NewPrefixed("failed creating deployment", err)
-		return diag.FromErr(merr.Append(newCreationError(reqID)))
+		return util.ErrorDiagnostics(merr.Append(newCreationError(reqID)))
 	}
 
-	if err := WaitForPlanCompletion(client, *res.ID); err != nil {
+	// When not waiting, the deployment's resources have no current plan yet,
+	// so the configured values are kept in the state instead of reading them.
+	if !d.Get("wait_for_completion").(bool) {
+		return diag.FromErr(keepPendingDeployment(d, providerMeta, res))
+	}
+
+	if err := waitForCreatePlanCompletion(ctx, client, *res.ID); err != nil {
+		// The deployment is kept in the state with the configured values rather
+		// than orphaned, it's refreshed once the plan has finished.
+		if errors.Is(err, errPlanStillRunning) {
+			if err := keepPendingDeployment(d, providerMeta, res); err != nil {
+				return diag.FromErr(err)
+			}
+			return diag.Diagnostics{{
+				Severity: diag.Warning,
+				Summary:  fmt.Sprintf("deployment %s: %s", *res.ID, err),
+				Detail: "The deployment has been kept in the state while its plan is still running. " +
+					"It's refreshed once the plan has finished, and the next update waits for the plan to finish.",
+			}}
+		}
+
 		merr := multierror.NewPrefixed("failed tracking create progress", err)
-		return diag.FromErr(merr.Append(newCreationError(reqID)))
+		// When interrupted, the deployment exists and is tracked in the state
+		// with its credentials, so it is marked as tainted instead of being
+		// left behind.
+		if ctx.Err() != nil {
+			if err := keepPendingDeployment(d, providerMeta, res); err != nil {
+				merr = merr.Append(err)
+			}
+			return diag.FromErr(merr)
+		}
+		merr = merr.Append(planFailureLogs(client, *res.ID)...)
+		merr = merr.Append(newConsoleURLError(providerMeta, *res.ID))
+		return util.ErrorDiagnostics(merr.Append(newCreationError(reqID)))
 	}
 
 	d.SetId(*res.ID)
 
-	if diag := read(ctx, d, meta); diag != nil {
-		return diag
+	// The credentials are only returned in the create response, so they're
+	// persisted before anything else can fail.
+	if err := parseCredentials(d, res.Resources); err != nil {
+		return diag.FromErr(err)
 	}
 
-	if err := parseCredentials(d, res.Resources); err != nil {
+	if err := handleKeystoreChange(d, client); err != nil {
 		return diag.FromErr(err)
 	}
 
+	if err := handleRemoteClustersChange(d, client); err != nil {
+		return diag.FromErr(err)
+	}
+
+	if diags := awaitHealthy(ctx, d, providerMeta); diags.HasError() {
+		return diags
+	}
+
+	return read(ctx, d, meta)
+}
+
+// keepPendingDeployment tracks the deployment in the state while its initial
+// plan is still running. The configured values are kept in the state, since
+// the deployment's resources have no current plan to read them from yet.
+func keepPendingDeployment(d *schema.ResourceData, providerMeta *util.ProviderMeta, res *models.DeploymentCreateResponse) error {
+	d.SetId(*res.ID)
+	if err := d.Set("console_url", providerMeta.DeploymentConsoleURL(*res.ID)); err != nil {
+		return err
+	}
+	return parseCredentials(d, res.Resources)
+}
+
+// newConsoleURLError returns the error pointing to the deployment's Cloud
+// console page, or nil when the console URL isn't known.
+func newConsoleURLError(providerMeta *util.ProviderMeta, id string) error {
+	if url := providerMeta.DeploymentConsoleURL(id); url != "" {
+		return fmt.Errorf("see %s for the deployment details", url)
+	}
 	return nil
 }
 
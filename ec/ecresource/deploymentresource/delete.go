@@ -20,18 +20,26 @@ package deploymentresource
 import (
 	"context"
 
-	"github.com/elastic/cloud-sdk-go/pkg/api"
 	"github.com/elastic/cloud-sdk-go/pkg/api/deploymentapi"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+
+	"github.com/elastic/terraform-provider-ec/ec/util"
 )
 
 // Delete shuts down and deletes the remote deployment.
-func delete(_ context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	client := meta.(*api.API)
+func delete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	providerMeta := meta.(*util.ProviderMeta)
+	client := providerMeta.API
+	defer util.LockDeployment(d.Id())()
+	defer forgetTrafficFilters(d, providerMeta)
 
-	if _, err := deploymentapi.Shutdown(deploymentapi.ShutdownParams{
-		API: client, DeploymentID: d.Id(),
+	if err := withTransientRetry(ctx, func() error {
+		_, err := deploymentapi.Shutdown(deploymentapi.ShutdownParams{
+			API: client, DeploymentID: d.Id(),
+			SkipSnapshot: providerMeta.Features.Deployment.SkipFinalSnapshot,
+		})
+		return err
 	}); err != nil {
 		return diag.FromErr(err)
 	}
@@ -40,7 +48,7 @@ func delete(_ context.Context, d *schema.ResourceData, meta interface{}) diag.Di
 		return diag.FromErr(err)
 	}
 
-	if err := handleTrafficFilterChange(d, client); err != nil {
+	if err := handleTrafficFilterChange(d, providerMeta); err != nil {
 		return diag.FromErr(err)
 	}
 
@@ -0,0 +1,25 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+// Package deploymentresource contains the ec_deployment resource. Besides the
+// resource itself, ExpandCreateRequest, ExpandUpdateRequest and
+// FlattenDeployment convert between resource data built from NewSchema and the
+// cloud-sdk-go deployment models, so they can be reused by external tooling
+// such as policy checks or configuration generators. The expanded requests
+// don't include the deployment template defaults, which ApplyTemplateDefaults
+// fills in from the API.
+package deploymentresource
@@ -18,9 +18,11 @@
 package elasticsearchstate
 
 import (
+	"fmt"
 	"reflect"
 
 	"github.com/elastic/cloud-sdk-go/pkg/models"
+	"github.com/elastic/cloud-sdk-go/pkg/multierror"
 	"github.com/elastic/cloud-sdk-go/pkg/util/ec"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 
@@ -34,14 +36,20 @@ func ExpandResources(ess []interface{}, dt string) ([]*models.ElasticsearchPaylo
 	}
 
 	result := make([]*models.ElasticsearchPayload, 0, len(ess))
+	var merr = multierror.NewPrefixed("invalid elasticsearch resource")
 	for _, raw := range ess {
 		resResource, err := expandResource(raw, dt)
 		if err != nil {
-			return nil, err
+			merr = merr.Append(err)
+			continue
 		}
 		result = append(result, resResource)
 	}
 
+	if err := merr.ErrorOrNil(); err != nil {
+		return nil, err
+	}
+
 	return result, nil
 }
 
@@ -87,6 +95,10 @@ func expandResource(raw interface{}, dt string) (*models.ElasticsearchPayload, e
 		}
 	}
 
+	if rawExtensions, ok := es["extension"]; ok {
+		expandExtensions(rawExtensions, res.Plan.Elasticsearch)
+	}
+
 	if rawSettings, ok := es["monitoring_settings"]; ok {
 		if settings := rawSettings.([]interface{}); len(settings) > 0 {
 			ms := settings[0].((map[string]interface{}))
@@ -105,20 +117,42 @@ func expandResource(raw interface{}, dt string) (*models.ElasticsearchPayload, e
 		// }
 	}
 
+	if rawSettings, ok := es["snapshot_settings"]; ok {
+		res.Settings.Snapshot = expandSnapshotSettings(rawSettings)
+	}
+
+	res.Settings.Trust = expandTrust(es["trust_account"], es["trust_external"])
+
 	return &res, nil
 }
 
-// ExpandTopology expands a flattened topology
+// ExpandTopology expands a flattened topology. Topology elements are
+// identified by their instance_configuration_id, which makes each element a
+// distinct tier (i.e. hot and warm), so it can't be repeated.
 func ExpandTopology(raw interface{}) ([]*models.ElasticsearchClusterTopologyElement, error) {
 	var rawTopologies = raw.([]interface{})
 	var res = make([]*models.ElasticsearchClusterTopologyElement, 0, len(rawTopologies))
-	for _, rawTop := range rawTopologies {
+	var merr = multierror.NewPrefixed("invalid elasticsearch resource")
+	var seen = make(map[string]int, len(rawTopologies))
+	for i, rawTop := range rawTopologies {
 		var topology = rawTop.(map[string]interface{})
 		var nodeType = parseNodeType(topology)
 
+		if id, ok := topology["instance_configuration_id"].(string); ok && id != "" {
+			if prev, ok := seen[id]; ok {
+				merr = merr.Append(fmt.Errorf(
+					`topology.%d: instance_configuration_id "%s" is already used by topology.%d`,
+					i, id, prev,
+				))
+				continue
+			}
+			seen[id] = i
+		}
+
 		size, err := util.ParseTopologySize(topology)
 		if err != nil {
-			return nil, err
+			merr = merr.Append(fmt.Errorf("topology.%d: %w", i, err))
+			continue
 		}
 
 		var elem = models.ElasticsearchClusterTopologyElement{
@@ -145,6 +179,10 @@ func ExpandTopology(raw interface{}) ([]*models.ElasticsearchClusterTopologyElem
 		res = append(res, &elem)
 	}
 
+	if err := merr.ErrorOrNil(); err != nil {
+		return nil, err
+	}
+
 	return res, nil
 }
 
@@ -169,6 +207,42 @@ func parseNodeType(topology map[string]interface{}) models.ElasticsearchNodeType
 	return result
 }
 
+// expandSnapshotSettings returns the cluster snapshot settings, or nil when none
+// are set so the current deployment settings are kept.
+func expandSnapshotSettings(raw interface{}) *models.ClusterSnapshotSettings {
+	settings, _ := raw.([]interface{})
+	if len(settings) == 0 {
+		return nil
+	}
+
+	ss, ok := settings[0].(map[string]interface{})
+	if !ok {
+		return nil
+	}
+
+	var res models.ClusterSnapshotSettings
+	if interval, ok := ss["interval"].(string); ok {
+		res.Interval = interval
+	}
+
+	var retention models.ClusterSnapshotRetention
+	if maxAge, ok := ss["retention_max_age"].(string); ok {
+		retention.MaxAge = maxAge
+	}
+	if snapshots, ok := ss["retention_snapshots"].(int); ok {
+		retention.Snapshots = int32(snapshots)
+	}
+	if retention != (models.ClusterSnapshotRetention{}) {
+		res.Retention = &retention
+	}
+
+	if res.Interval == "" && res.Retention == nil {
+		return nil
+	}
+
+	return &res
+}
+
 func expandConfig(raw interface{}) *models.ElasticsearchConfiguration {
 	var res = &models.ElasticsearchConfiguration{}
 	for _, rawCfg := range raw.([]interface{}) {
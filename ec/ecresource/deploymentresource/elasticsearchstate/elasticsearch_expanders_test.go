@@ -18,10 +18,12 @@
 package elasticsearchstate
 
 import (
+	"errors"
 	"testing"
 
 	"github.com/elastic/cloud-sdk-go/pkg/api/mock"
 	"github.com/elastic/cloud-sdk-go/pkg/models"
+	"github.com/elastic/cloud-sdk-go/pkg/multierror"
 	"github.com/elastic/cloud-sdk-go/pkg/util/ec"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/stretchr/testify/assert"
@@ -230,6 +232,177 @@ func TestExpandResource(t *testing.T) {
 				},
 			},
 		},
+		{
+			name: "parses an ES resource with hot and warm topology elements",
+			args: args{
+				dt: "deployment-template-id",
+				ess: []interface{}{
+					map[string]interface{}{
+						"ref_id":  "main-elasticsearch",
+						"version": "7.9.2",
+						"topology": []interface{}{
+							map[string]interface{}{
+								"instance_configuration_id": "aws.data.highio.i3",
+								"memory_per_node":           "4g",
+								"node_type_data":            true,
+								"node_type_ingest":          true,
+								"node_type_master":          true,
+								"zone_count":                2,
+							},
+							map[string]interface{}{
+								"instance_configuration_id": "aws.data.highstorage.d2",
+								"memory_per_node":           "8g",
+								"node_type_data":            true,
+								"node_type_ingest":          false,
+								"node_type_master":          false,
+								"zone_count":                2,
+							},
+						},
+					},
+				},
+			},
+			want: []*models.ElasticsearchPayload{
+				{
+					RefID:    ec.String("main-elasticsearch"),
+					Settings: &models.ElasticsearchClusterSettings{},
+					Plan: &models.ElasticsearchClusterPlan{
+						Elasticsearch: &models.ElasticsearchConfiguration{
+							Version: "7.9.2",
+						},
+						DeploymentTemplate: &models.DeploymentTemplateReference{
+							ID: ec.String("deployment-template-id"),
+						},
+						ClusterTopology: []*models.ElasticsearchClusterTopologyElement{
+							{
+								ZoneCount:               2,
+								InstanceConfigurationID: "aws.data.highio.i3",
+								Size: &models.TopologySize{
+									Resource: ec.String("memory"),
+									Value:    ec.Int32(4096),
+								},
+								NodeType: &models.ElasticsearchNodeType{
+									Data:   ec.Bool(true),
+									Ingest: ec.Bool(true),
+									Master: ec.Bool(true),
+								},
+							},
+							{
+								ZoneCount:               2,
+								InstanceConfigurationID: "aws.data.highstorage.d2",
+								Size: &models.TopologySize{
+									Resource: ec.String("memory"),
+									Value:    ec.Int32(8192),
+								},
+								NodeType: &models.ElasticsearchNodeType{
+									Data:   ec.Bool(true),
+									Ingest: ec.Bool(false),
+									Master: ec.Bool(false),
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+		{
+			name: "parses an ES resource with a coordinating topology element",
+			args: args{
+				dt: "deployment-template-id",
+				ess: []interface{}{
+					map[string]interface{}{
+						"ref_id":  "main-elasticsearch",
+						"version": "7.9.2",
+						"topology": []interface{}{
+							map[string]interface{}{
+								"instance_configuration_id": "aws.data.highio.i3",
+								"memory_per_node":           "8g",
+								"node_type_data":            true,
+								"node_type_ingest":          false,
+								"node_type_master":          true,
+								"zone_count":                2,
+							},
+							map[string]interface{}{
+								"instance_configuration_id": "aws.coordinating.m5d",
+								"memory_per_node":           "2g",
+								"node_type_data":            false,
+								"node_type_ingest":          true,
+								"node_type_master":          false,
+								"zone_count":                2,
+							},
+						},
+					},
+				},
+			},
+			want: []*models.ElasticsearchPayload{
+				{
+					RefID:    ec.String("main-elasticsearch"),
+					Settings: &models.ElasticsearchClusterSettings{},
+					Plan: &models.ElasticsearchClusterPlan{
+						Elasticsearch: &models.ElasticsearchConfiguration{
+							Version: "7.9.2",
+						},
+						DeploymentTemplate: &models.DeploymentTemplateReference{
+							ID: ec.String("deployment-template-id"),
+						},
+						ClusterTopology: []*models.ElasticsearchClusterTopologyElement{
+							{
+								ZoneCount:               2,
+								InstanceConfigurationID: "aws.data.highio.i3",
+								Size: &models.TopologySize{
+									Resource: ec.String("memory"),
+									Value:    ec.Int32(8192),
+								},
+								NodeType: &models.ElasticsearchNodeType{
+									Data:   ec.Bool(true),
+									Ingest: ec.Bool(false),
+									Master: ec.Bool(true),
+								},
+							},
+							{
+								ZoneCount:               2,
+								InstanceConfigurationID: "aws.coordinating.m5d",
+								Size: &models.TopologySize{
+									Resource: ec.String("memory"),
+									Value:    ec.Int32(2048),
+								},
+								NodeType: &models.ElasticsearchNodeType{
+									Data:   ec.Bool(false),
+									Ingest: ec.Bool(true),
+									Master: ec.Bool(false),
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+		{
+			name: "fails on repeated topology elements",
+			args: args{
+				dt: "deployment-template-id",
+				ess: []interface{}{
+					map[string]interface{}{
+						"ref_id":  "main-elasticsearch",
+						"version": "7.9.2",
+						"topology": []interface{}{
+							map[string]interface{}{
+								"instance_configuration_id": "aws.data.highio.i3",
+								"memory_per_node":           "4g",
+							},
+							map[string]interface{}{
+								"instance_configuration_id": "aws.data.highio.i3",
+								"memory_per_node":           "8g",
+							},
+						},
+					},
+				},
+			},
+			err: multierror.NewPrefixed("invalid elasticsearch resource",
+				multierror.NewPrefixed("invalid elasticsearch resource",
+					errors.New(`topology.1: instance_configuration_id "aws.data.highio.i3" is already used by topology.0`),
+				),
+			),
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -242,3 +415,46 @@ func TestExpandResource(t *testing.T) {
 		})
 	}
 }
+
+func Test_expandSnapshotSettings(t *testing.T) {
+	tests := []struct {
+		name string
+		raw  interface{}
+		want *models.ClusterSnapshotSettings
+	}{
+		{
+			name: "returns nil without settings",
+			raw:  []interface{}{},
+		},
+		{
+			name: "returns nil when no values are set",
+			raw:  []interface{}{map[string]interface{}{"interval": "", "retention_snapshots": 0}},
+		},
+		{
+			name: "expands the interval",
+			raw:  []interface{}{map[string]interface{}{"interval": "4h"}},
+			want: &models.ClusterSnapshotSettings{Interval: "4h"},
+		},
+		{
+			name: "expands the interval and retention",
+			raw: []interface{}{map[string]interface{}{
+				"interval":            "30min",
+				"retention_max_age":   "7d",
+				"retention_snapshots": 100,
+			}},
+			want: &models.ClusterSnapshotSettings{
+				Interval: "30min",
+				Retention: &models.ClusterSnapshotRetention{
+					MaxAge:    "7d",
+					Snapshots: 100,
+				},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := expandSnapshotSettings(tt.raw)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
@@ -0,0 +1,85 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package elasticsearchstate
+
+import (
+	"github.com/elastic/cloud-sdk-go/pkg/models"
+	"github.com/elastic/cloud-sdk-go/pkg/util/ec"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+// Extension types, which map to the user_bundles and user_plugins settings.
+const (
+	extensionTypeBundle = "bundle"
+	extensionTypePlugin = "plugin"
+)
+
+// expandExtensions sets the flattened extensions as the configuration's user
+// bundles and plugins.
+func expandExtensions(raw interface{}, cfg *models.ElasticsearchConfiguration) {
+	set, ok := raw.(*schema.Set)
+	if !ok {
+		return
+	}
+
+	for _, rawExt := range set.List() {
+		var ext = rawExt.(map[string]interface{})
+		var name, version, url = ec.String(ext["name"].(string)),
+			ec.String(ext["version"].(string)),
+			ec.String(ext["url"].(string))
+
+		switch ext["type"].(string) {
+		case extensionTypeBundle:
+			cfg.UserBundles = append(cfg.UserBundles, &models.ElasticsearchUserBundle{
+				Name: name, ElasticsearchVersion: version, URL: url,
+			})
+		case extensionTypePlugin:
+			cfg.UserPlugins = append(cfg.UserPlugins, &models.ElasticsearchUserPlugin{
+				Name: name, ElasticsearchVersion: version, URL: url,
+			})
+		}
+	}
+}
+
+// flattenExtensions flattens the configuration's user bundles and plugins.
+func flattenExtensions(cfg *models.ElasticsearchConfiguration) []interface{} {
+	if cfg == nil {
+		return nil
+	}
+
+	var result = make([]interface{}, 0, len(cfg.UserBundles)+len(cfg.UserPlugins))
+	for _, bundle := range cfg.UserBundles {
+		result = append(result, map[string]interface{}{
+			"type":    extensionTypeBundle,
+			"name":    *bundle.Name,
+			"version": *bundle.ElasticsearchVersion,
+			"url":     *bundle.URL,
+		})
+	}
+
+	for _, plugin := range cfg.UserPlugins {
+		result = append(result, map[string]interface{}{
+			"type":    extensionTypePlugin,
+			"name":    *plugin.Name,
+			"version": *plugin.ElasticsearchVersion,
+			"url":     *plugin.URL,
+		})
+	}
+
+	return result
+}
@@ -0,0 +1,117 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package elasticsearchstate
+
+import (
+	"testing"
+
+	"github.com/elastic/cloud-sdk-go/pkg/models"
+	"github.com/elastic/cloud-sdk-go/pkg/util/ec"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_expandExtensions(t *testing.T) {
+	var hash = func(v interface{}) int {
+		return schema.HashString(v.(map[string]interface{})["name"])
+	}
+	tests := []struct {
+		name string
+		raw  interface{}
+		want *models.ElasticsearchConfiguration
+	}{
+		{
+			name: "ignores a missing extension set",
+			want: &models.ElasticsearchConfiguration{},
+		},
+		{
+			name: "expands bundles and plugins",
+			raw: schema.NewSet(hash, []interface{}{
+				map[string]interface{}{
+					"type": "bundle", "name": "synonyms",
+					"version": "7.10.1", "url": "repo://1",
+				},
+				map[string]interface{}{
+					"type": "plugin", "name": "my-plugin",
+					"version": "7.10.1", "url": "repo://2",
+				},
+			}),
+			want: &models.ElasticsearchConfiguration{
+				UserBundles: []*models.ElasticsearchUserBundle{{
+					Name:                 ec.String("synonyms"),
+					ElasticsearchVersion: ec.String("7.10.1"),
+					URL:                  ec.String("repo://1"),
+				}},
+				UserPlugins: []*models.ElasticsearchUserPlugin{{
+					Name:                 ec.String("my-plugin"),
+					ElasticsearchVersion: ec.String("7.10.1"),
+					URL:                  ec.String("repo://2"),
+				}},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var cfg models.ElasticsearchConfiguration
+			expandExtensions(tt.raw, &cfg)
+			assert.Equal(t, tt.want, &cfg)
+		})
+	}
+}
+
+func Test_flattenExtensions(t *testing.T) {
+	tests := []struct {
+		name string
+		cfg  *models.ElasticsearchConfiguration
+		want []interface{}
+	}{
+		{
+			name: "returns nil without a configuration",
+		},
+		{
+			name: "flattens bundles and plugins",
+			cfg: &models.ElasticsearchConfiguration{
+				UserBundles: []*models.ElasticsearchUserBundle{{
+					Name:                 ec.String("synonyms"),
+					ElasticsearchVersion: ec.String("7.10.1"),
+					URL:                  ec.String("repo://1"),
+				}},
+				UserPlugins: []*models.ElasticsearchUserPlugin{{
+					Name:                 ec.String("my-plugin"),
+					ElasticsearchVersion: ec.String("7.10.1"),
+					URL:                  ec.String("repo://2"),
+				}},
+			},
+			want: []interface{}{
+				map[string]interface{}{
+					"type": "bundle", "name": "synonyms",
+					"version": "7.10.1", "url": "repo://1",
+				},
+				map[string]interface{}{
+					"type": "plugin", "name": "my-plugin",
+					"version": "7.10.1", "url": "repo://2",
+				},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, flattenExtensions(tt.cfg))
+		})
+	}
+}
@@ -72,6 +72,10 @@ func FlattenResources(in []*models.ElasticsearchResourceInfo, name string) []int
 			m["config"] = c
 		}
 
+		if extensions := flattenExtensions(plan.Elasticsearch); len(extensions) > 0 {
+			m["extension"] = extensions
+		}
+
 		result = append(result, m)
 	}
 
@@ -95,8 +99,11 @@ func flattenTopology(plan *models.ElasticsearchClusterPlan) []interface{} {
 		// 	m["memory_per_node"] = strconv.Itoa(int(topology.MemoryPerNode))
 		// }
 
-		if *topology.Size.Resource == "memory" {
+		// The size is kept in the memory_per_node notation regardless of
+		// the resource it applies to.
+		if topology.Size.Resource != nil {
 			m["memory_per_node"] = util.MemoryToState(*topology.Size.Value)
+			m["size_resource"] = *topology.Size.Resource
 		}
 
 		if nt := topology.NodeType; nt != nil {
@@ -130,6 +137,8 @@ func flattenTopology(plan *models.ElasticsearchClusterPlan) []interface{} {
 		result = append(result, m)
 	}
 
+	util.SortTopology(result)
+
 	return result
 }
 
@@ -187,9 +196,49 @@ func flattenElasticsearchSettings(info *models.ElasticsearchClusterInfo) map[str
 		}}
 	}
 
+	if info.Settings != nil {
+		if snapshot := flattenSnapshotSettings(info.Settings.Snapshot); len(snapshot) > 0 {
+			m["snapshot_settings"] = snapshot
+		}
+
+		accounts, external := flattenTrust(info.Settings.Trust)
+		if len(accounts) > 0 {
+			m["trust_account"] = accounts
+		}
+		if len(external) > 0 {
+			m["trust_external"] = external
+		}
+	}
+
 	return m
 }
 
+func flattenSnapshotSettings(settings *models.ClusterSnapshotSettings) []interface{} {
+	if settings == nil {
+		return nil
+	}
+
+	var m = make(map[string]interface{})
+	if settings.Interval != "" {
+		m["interval"] = settings.Interval
+	}
+
+	if settings.Retention != nil {
+		if settings.Retention.MaxAge != "" {
+			m["retention_max_age"] = settings.Retention.MaxAge
+		}
+		if settings.Retention.Snapshots > 0 {
+			m["retention_snapshots"] = settings.Retention.Snapshots
+		}
+	}
+
+	if len(m) == 0 {
+		return nil
+	}
+
+	return []interface{}{m}
+}
+
 // IsCurrentPlanEmpty checks the elasticsearch resource current plan is empty.
 func IsCurrentPlanEmpty(res *models.ElasticsearchResourceInfo) bool {
 	return res.Info == nil || res.Info.PlanInfo == nil ||
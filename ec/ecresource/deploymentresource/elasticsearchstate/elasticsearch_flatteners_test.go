@@ -113,6 +113,7 @@ func TestFlattenResource(t *testing.T) {
 						map[string]interface{}{
 							"instance_configuration_id": "aws.data.highio.i3",
 							"memory_per_node":           "2g",
+							"size_resource":             "memory",
 							"node_type_data":            true,
 							"node_type_ingest":          true,
 							"node_type_master":          true,
@@ -185,6 +186,7 @@ func TestFlattenResource(t *testing.T) {
 						map[string]interface{}{
 							"instance_configuration_id": "aws.data.highio.i3",
 							"memory_per_node":           "2g",
+							"size_resource":             "memory",
 							"node_type_data":            true,
 							"node_type_ingest":          true,
 							"node_type_master":          true,
@@ -257,6 +259,7 @@ func TestFlattenResource(t *testing.T) {
 				"topology": []interface{}{map[string]interface{}{
 					"instance_configuration_id": "aws.data.highio.i3",
 					"memory_per_node":           "2g",
+					"size_resource":             "memory",
 					"node_type_data":            true,
 					"node_type_ingest":          true,
 					"node_type_master":          true,
@@ -318,6 +321,7 @@ func TestFlattenTopology(t *testing.T) {
 				map[string]interface{}{
 					"instance_configuration_id": "aws.data.highio.i3",
 					"memory_per_node":           "4g",
+					"size_resource":             "memory",
 					"zone_count":                int32(1),
 					"node_type_data":            true,
 					"node_type_ingest":          true,
@@ -325,6 +329,147 @@ func TestFlattenTopology(t *testing.T) {
 				},
 			},
 		},
+		{
+			name: "sorts the topologies by instance_configuration_id",
+			args: args{plan: &models.ElasticsearchClusterPlan{
+				ClusterTopology: []*models.ElasticsearchClusterTopologyElement{
+					{
+						ZoneCount:               1,
+						InstanceConfigurationID: "aws.ml.m5",
+						Size: &models.TopologySize{
+							Value: ec.Int32(1024), Resource: ec.String("memory"),
+						},
+					},
+					{
+						ZoneCount:               2,
+						InstanceConfigurationID: "aws.data.highio.i3",
+						Size: &models.TopologySize{
+							Value: ec.Int32(4096), Resource: ec.String("memory"),
+						},
+					},
+				},
+			}},
+			want: []interface{}{
+				map[string]interface{}{
+					"instance_configuration_id": "aws.data.highio.i3",
+					"memory_per_node":           "4g",
+					"size_resource":             "memory",
+					"zone_count":                int32(2),
+				},
+				map[string]interface{}{
+					"instance_configuration_id": "aws.ml.m5",
+					"memory_per_node":           "1g",
+					"size_resource":             "memory",
+					"zone_count":                int32(1),
+				},
+			},
+		},
+		{
+			name: "keeps the dedicated master topology element apart",
+			args: args{plan: &models.ElasticsearchClusterPlan{
+				ClusterTopology: []*models.ElasticsearchClusterTopologyElement{
+					{
+						ZoneCount:               3,
+						InstanceConfigurationID: "aws.data.highio.i3",
+						Size: &models.TopologySize{
+							Value: ec.Int32(8192), Resource: ec.String("memory"),
+						},
+						NodeType: &models.ElasticsearchNodeType{
+							Data:   ec.Bool(true),
+							Ingest: ec.Bool(true),
+							Master: ec.Bool(false),
+						},
+					},
+					{
+						ZoneCount:               3,
+						InstanceConfigurationID: "aws.master.r5d",
+						Size: &models.TopologySize{
+							Value: ec.Int32(1024), Resource: ec.String("memory"),
+						},
+						NodeType: &models.ElasticsearchNodeType{
+							Data:   ec.Bool(false),
+							Ingest: ec.Bool(false),
+							Master: ec.Bool(true),
+						},
+					},
+				},
+			}},
+			want: []interface{}{
+				map[string]interface{}{
+					"instance_configuration_id": "aws.data.highio.i3",
+					"memory_per_node":           "8g",
+					"size_resource":             "memory",
+					"zone_count":                int32(3),
+					"node_type_data":            true,
+					"node_type_ingest":          true,
+					"node_type_master":          false,
+				},
+				map[string]interface{}{
+					"instance_configuration_id": "aws.master.r5d",
+					"memory_per_node":           "1g",
+					"size_resource":             "memory",
+					"zone_count":                int32(3),
+					"node_type_data":            false,
+					"node_type_ingest":          false,
+					"node_type_master":          true,
+				},
+			},
+		},
+		{
+			name: "keeps the machine learning topology element apart",
+			args: args{plan: &models.ElasticsearchClusterPlan{
+				ClusterTopology: []*models.ElasticsearchClusterTopologyElement{
+					{
+						ZoneCount:               2,
+						InstanceConfigurationID: "aws.data.highio.i3",
+						Size: &models.TopologySize{
+							Value: ec.Int32(4096), Resource: ec.String("memory"),
+						},
+						NodeType: &models.ElasticsearchNodeType{
+							Data:   ec.Bool(true),
+							Ingest: ec.Bool(true),
+							Master: ec.Bool(true),
+							Ml:     ec.Bool(false),
+						},
+					},
+					{
+						ZoneCount:               1,
+						InstanceConfigurationID: "aws.ml.m5",
+						Size: &models.TopologySize{
+							Value: ec.Int32(2048), Resource: ec.String("memory"),
+						},
+						NodeType: &models.ElasticsearchNodeType{
+							Data:   ec.Bool(false),
+							Ingest: ec.Bool(false),
+							Master: ec.Bool(false),
+							Ml:     ec.Bool(true),
+						},
+					},
+				},
+			}},
+			want: []interface{}{
+				map[string]interface{}{
+					"instance_configuration_id": "aws.data.highio.i3",
+					"memory_per_node":           "4g",
+					"size_resource":             "memory",
+					"zone_count":                int32(2),
+					"node_type_data":            true,
+					"node_type_ingest":          true,
+					"node_type_master":          true,
+					"node_type_ml":              false,
+				},
+				map[string]interface{}{
+					"instance_configuration_id": "aws.ml.m5",
+					"memory_per_node":           "2g",
+					"size_resource":             "memory",
+					"zone_count":                int32(1),
+					"node_type_data":            false,
+					"node_type_ingest":          false,
+					"node_type_master":          false,
+					"node_type_ml":              true,
+				},
+			},
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -368,3 +513,40 @@ func Test_flattenConfig(t *testing.T) {
 		})
 	}
 }
+
+func Test_flattenSnapshotSettings(t *testing.T) {
+	tests := []struct {
+		name     string
+		settings *models.ClusterSnapshotSettings
+		want     []interface{}
+	}{
+		{
+			name: "returns nil without settings",
+		},
+		{
+			name:     "returns nil without interval or retention",
+			settings: &models.ClusterSnapshotSettings{Enabled: ec.Bool(true)},
+		},
+		{
+			name: "flattens the interval and retention",
+			settings: &models.ClusterSnapshotSettings{
+				Interval: "30min",
+				Retention: &models.ClusterSnapshotRetention{
+					MaxAge:    "7d",
+					Snapshots: 100,
+				},
+			},
+			want: []interface{}{map[string]interface{}{
+				"interval":            "30min",
+				"retention_max_age":   "7d",
+				"retention_snapshots": int32(100),
+			}},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := flattenSnapshotSettings(tt.settings)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
@@ -0,0 +1,90 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package elasticsearchstate
+
+import (
+	"github.com/elastic/cloud-sdk-go/pkg/models"
+	"github.com/elastic/cloud-sdk-go/pkg/util/ec"
+)
+
+// ExpandRemoteClusters expands the remote_cluster elements into the full list
+// of remote clusters, which replaces the Elasticsearch resource's current one.
+func ExpandRemoteClusters(raw []interface{}) *models.RemoteResources {
+	// The API expects an empty list rather than null to remove all the
+	// remote clusters.
+	var res = models.RemoteResources{
+		Resources: make([]*models.RemoteResourceRef, 0, len(raw)),
+	}
+
+	for _, elem := range raw {
+		rc, ok := elem.(map[string]interface{})
+		if !ok {
+			continue
+		}
+
+		var ref models.RemoteResourceRef
+		if id, ok := rc["deployment_id"].(string); ok {
+			ref.DeploymentID = ec.String(id)
+		}
+		if refID, ok := rc["ref_id"].(string); ok {
+			ref.ElasticsearchRefID = ec.String(refID)
+		}
+		if alias, ok := rc["alias"].(string); ok {
+			ref.Alias = ec.String(alias)
+		}
+		if skip, ok := rc["skip_unavailable"].(bool); ok {
+			ref.SkipUnavailable = ec.Bool(skip)
+		}
+
+		res.Resources = append(res.Resources, &ref)
+	}
+
+	return &res
+}
+
+// FlattenRemoteClusters flattens the Elasticsearch resource's remote clusters.
+func FlattenRemoteClusters(res *models.RemoteResources) []interface{} {
+	if res == nil {
+		return nil
+	}
+
+	var result = make([]interface{}, 0, len(res.Resources))
+	for _, ref := range res.Resources {
+		if ref == nil {
+			continue
+		}
+
+		var m = make(map[string]interface{})
+		if ref.DeploymentID != nil {
+			m["deployment_id"] = *ref.DeploymentID
+		}
+		if ref.ElasticsearchRefID != nil {
+			m["ref_id"] = *ref.ElasticsearchRefID
+		}
+		if ref.Alias != nil {
+			m["alias"] = *ref.Alias
+		}
+		if ref.SkipUnavailable != nil {
+			m["skip_unavailable"] = *ref.SkipUnavailable
+		}
+
+		result = append(result, m)
+	}
+
+	return result
+}
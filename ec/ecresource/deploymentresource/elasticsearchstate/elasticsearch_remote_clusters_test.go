@@ -0,0 +1,96 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package elasticsearchstate
+
+import (
+	"testing"
+
+	"github.com/elastic/cloud-sdk-go/pkg/api/mock"
+	"github.com/elastic/cloud-sdk-go/pkg/models"
+	"github.com/elastic/cloud-sdk-go/pkg/util/ec"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestExpandRemoteClusters(t *testing.T) {
+	tests := []struct {
+		name string
+		raw  []interface{}
+		want *models.RemoteResources
+	}{
+		{
+			name: "expands an empty list to remove all the remote clusters",
+			want: &models.RemoteResources{Resources: []*models.RemoteResourceRef{}},
+		},
+		{
+			name: "expands the remote clusters",
+			raw: []interface{}{
+				map[string]interface{}{
+					"deployment_id":    mock.ValidClusterID,
+					"ref_id":           "main-elasticsearch",
+					"alias":            "my-remote",
+					"skip_unavailable": true,
+				},
+			},
+			want: &models.RemoteResources{Resources: []*models.RemoteResourceRef{{
+				DeploymentID:       ec.String(mock.ValidClusterID),
+				ElasticsearchRefID: ec.String("main-elasticsearch"),
+				Alias:              ec.String("my-remote"),
+				SkipUnavailable:    ec.Bool(true),
+			}}},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := ExpandRemoteClusters(tt.raw)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestFlattenRemoteClusters(t *testing.T) {
+	tests := []struct {
+		name string
+		res  *models.RemoteResources
+		want []interface{}
+	}{
+		{
+			name: "returns nil on an empty response",
+		},
+		{
+			name: "flattens the remote clusters",
+			res: &models.RemoteResources{Resources: []*models.RemoteResourceRef{{
+				DeploymentID:       ec.String(mock.ValidClusterID),
+				ElasticsearchRefID: ec.String("main-elasticsearch"),
+				Alias:              ec.String("my-remote"),
+				SkipUnavailable:    ec.Bool(false),
+			}}},
+			want: []interface{}{map[string]interface{}{
+				"deployment_id":    mock.ValidClusterID,
+				"ref_id":           "main-elasticsearch",
+				"alias":            "my-remote",
+				"skip_unavailable": false,
+			}},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := FlattenRemoteClusters(tt.res)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
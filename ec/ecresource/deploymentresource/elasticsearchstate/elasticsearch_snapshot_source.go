@@ -0,0 +1,51 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package elasticsearchstate
+
+import (
+	"github.com/elastic/cloud-sdk-go/pkg/models"
+	"github.com/elastic/cloud-sdk-go/pkg/util/ec"
+)
+
+// ExpandSnapshotSource sets the plan's transient restore settings from the
+// snapshot_source block, so the Elasticsearch cluster is restored from the
+// snapshot when it's created. It must only be used for create requests, since
+// a restore on update would overwrite the cluster's data.
+func ExpandSnapshotSource(raw interface{}, res *models.ElasticsearchPayload) {
+	sources, _ := raw.([]interface{})
+	if len(sources) == 0 || res == nil || res.Plan == nil {
+		return
+	}
+
+	source, ok := sources[0].(map[string]interface{})
+	if !ok {
+		return
+	}
+
+	name, _ := source["snapshot_name"].(string)
+	id, _ := source["source_elasticsearch_cluster_id"].(string)
+	var restore = models.RestoreSnapshotConfiguration{
+		SnapshotName:    ec.String(name),
+		SourceClusterID: id,
+	}
+
+	if res.Plan.Transient == nil {
+		res.Plan.Transient = &models.TransientElasticsearchPlanConfiguration{}
+	}
+	res.Plan.Transient.RestoreSnapshot = &restore
+}
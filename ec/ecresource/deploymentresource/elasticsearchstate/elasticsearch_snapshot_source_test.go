@@ -0,0 +1,94 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package elasticsearchstate
+
+import (
+	"testing"
+
+	"github.com/elastic/cloud-sdk-go/pkg/models"
+	"github.com/elastic/cloud-sdk-go/pkg/util/ec"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestExpandSnapshotSource(t *testing.T) {
+	type args struct {
+		raw interface{}
+		res *models.ElasticsearchPayload
+	}
+	tests := []struct {
+		name string
+		args args
+		want *models.ElasticsearchPayload
+	}{
+		{
+			name: "leaves the payload as is without a snapshot source",
+			args: args{
+				raw: []interface{}{},
+				res: &models.ElasticsearchPayload{Plan: &models.ElasticsearchClusterPlan{}},
+			},
+			want: &models.ElasticsearchPayload{Plan: &models.ElasticsearchClusterPlan{}},
+		},
+		{
+			name: "sets the transient restore settings",
+			args: args{
+				raw: []interface{}{map[string]interface{}{
+					"source_elasticsearch_cluster_id": "some-cluster-id",
+					"snapshot_name":                   "__latest_success__",
+				}},
+				res: &models.ElasticsearchPayload{Plan: &models.ElasticsearchClusterPlan{}},
+			},
+			want: &models.ElasticsearchPayload{Plan: &models.ElasticsearchClusterPlan{
+				Transient: &models.TransientElasticsearchPlanConfiguration{
+					RestoreSnapshot: &models.RestoreSnapshotConfiguration{
+						SourceClusterID: "some-cluster-id",
+						SnapshotName:    ec.String("__latest_success__"),
+					},
+				},
+			}},
+		},
+		{
+			name: "keeps the existing transient settings",
+			args: args{
+				raw: []interface{}{map[string]interface{}{
+					"source_elasticsearch_cluster_id": "some-cluster-id",
+					"snapshot_name":                   "my-snapshot",
+				}},
+				res: &models.ElasticsearchPayload{Plan: &models.ElasticsearchClusterPlan{
+					Transient: &models.TransientElasticsearchPlanConfiguration{
+						Strategy: &models.PlanStrategy{},
+					},
+				}},
+			},
+			want: &models.ElasticsearchPayload{Plan: &models.ElasticsearchClusterPlan{
+				Transient: &models.TransientElasticsearchPlanConfiguration{
+					Strategy: &models.PlanStrategy{},
+					RestoreSnapshot: &models.RestoreSnapshotConfiguration{
+						SourceClusterID: "some-cluster-id",
+						SnapshotName:    ec.String("my-snapshot"),
+					},
+				},
+			}},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ExpandSnapshotSource(tt.args.raw, tt.args.res)
+			assert.Equal(t, tt.want, tt.args.res)
+		})
+	}
+}
@@ -0,0 +1,153 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package elasticsearchstate
+
+import (
+	"github.com/elastic/cloud-sdk-go/pkg/models"
+	"github.com/elastic/cloud-sdk-go/pkg/util/ec"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+// expandTrust returns the cluster trust settings from the trust_account and
+// trust_external elements, or nil when neither are set so the current
+// deployment settings are kept. See ResetTrust to remove them.
+func expandTrust(rawAccounts, rawExternal interface{}) *models.ElasticsearchClusterTrustSettings {
+	accounts, _ := rawAccounts.(*schema.Set)
+	external, _ := rawExternal.(*schema.Set)
+	if (accounts == nil || accounts.Len() == 0) && (external == nil || external.Len() == 0) {
+		return nil
+	}
+
+	// Both lists are always sent, since a null list removes its trust
+	// relationships.
+	var res = models.ElasticsearchClusterTrustSettings{
+		Accounts: make([]*models.AccountTrustRelationship, 0),
+		External: make([]*models.ExternalTrustRelationship, 0),
+	}
+
+	if accounts != nil {
+		for _, raw := range accounts.List() {
+			var account = raw.(map[string]interface{})
+			res.Accounts = append(res.Accounts, &models.AccountTrustRelationship{
+				AccountID:      ec.String(account["account_id"].(string)),
+				TrustAll:       ec.Bool(account["trust_all"].(bool)),
+				TrustWhitelist: expandAllowlist(account["trust_allowlist"]),
+			})
+		}
+	}
+
+	if external != nil {
+		for _, raw := range external.List() {
+			var ext = raw.(map[string]interface{})
+			res.External = append(res.External, &models.ExternalTrustRelationship{
+				TrustRelationshipID: ec.String(ext["relationship_id"].(string)),
+				TrustAll:            ec.Bool(ext["trust_all"].(bool)),
+				TrustWhitelist:      expandAllowlist(ext["trust_allowlist"]),
+			})
+		}
+	}
+
+	return &res
+}
+
+// ResetTrust restores the deployment's default trust settings in the
+// Elasticsearch payload when none are set, which removes the trust
+// relationships once the last trust_account or trust_external element is
+// removed. The default trust of the deployment's own account is kept, so
+// cross cluster search and replication within the account keep working. The
+// own account trust is only omitted when its ID isn't known.
+func ResetTrust(res *models.ElasticsearchPayload, ownAccountID string) {
+	if res == nil {
+		return
+	}
+
+	if res.Settings == nil {
+		res.Settings = &models.ElasticsearchClusterSettings{}
+	}
+
+	if res.Settings.Trust != nil {
+		return
+	}
+
+	res.Settings.Trust = &models.ElasticsearchClusterTrustSettings{
+		Accounts: make([]*models.AccountTrustRelationship, 0, 1),
+		External: make([]*models.ExternalTrustRelationship, 0),
+	}
+
+	if ownAccountID != "" {
+		res.Settings.Trust.Accounts = append(res.Settings.Trust.Accounts,
+			&models.AccountTrustRelationship{
+				AccountID: ec.String(ownAccountID),
+				TrustAll:  ec.Bool(true),
+			},
+		)
+	}
+}
+
+func expandAllowlist(raw interface{}) []string {
+	set, ok := raw.(*schema.Set)
+	if !ok {
+		return nil
+	}
+
+	var result = make([]string, 0, set.Len())
+	for _, id := range set.List() {
+		result = append(result, id.(string))
+	}
+	return result
+}
+
+// flattenTrust flattens the cluster trust settings into the trust_account and
+// trust_external elements.
+func flattenTrust(settings *models.ElasticsearchClusterTrustSettings) (accounts, external []interface{}) {
+	if settings == nil {
+		return nil, nil
+	}
+
+	for _, account := range settings.Accounts {
+		if account == nil || account.AccountID == nil {
+			continue
+		}
+		accounts = append(accounts, map[string]interface{}{
+			"account_id":      *account.AccountID,
+			"trust_all":       account.TrustAll != nil && *account.TrustAll,
+			"trust_allowlist": flattenAllowlist(account.TrustWhitelist),
+		})
+	}
+
+	for _, ext := range settings.External {
+		if ext == nil || ext.TrustRelationshipID == nil {
+			continue
+		}
+		external = append(external, map[string]interface{}{
+			"relationship_id": *ext.TrustRelationshipID,
+			"trust_all":       ext.TrustAll != nil && *ext.TrustAll,
+			"trust_allowlist": flattenAllowlist(ext.TrustWhitelist),
+		})
+	}
+
+	return accounts, external
+}
+
+func flattenAllowlist(ids []string) []interface{} {
+	var result = make([]interface{}, 0, len(ids))
+	for _, id := range ids {
+		result = append(result, id)
+	}
+	return result
+}
@@ -0,0 +1,194 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package elasticsearchstate
+
+import (
+	"testing"
+
+	"github.com/elastic/cloud-sdk-go/pkg/models"
+	"github.com/elastic/cloud-sdk-go/pkg/util/ec"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_expandTrust(t *testing.T) {
+	var hash = func(v interface{}) int {
+		m := v.(map[string]interface{})
+		if id, ok := m["account_id"]; ok {
+			return schema.HashString(id)
+		}
+		return schema.HashString(m["relationship_id"])
+	}
+	type args struct {
+		accounts interface{}
+		external interface{}
+	}
+	tests := []struct {
+		name string
+		args args
+		want *models.ElasticsearchClusterTrustSettings
+	}{
+		{
+			name: "returns nil without trust settings",
+			args: args{
+				accounts: schema.NewSet(hash, nil),
+				external: schema.NewSet(hash, nil),
+			},
+		},
+		{
+			name: "expands the account trust",
+			args: args{
+				accounts: schema.NewSet(hash, []interface{}{
+					map[string]interface{}{
+						"account_id":      "some-account",
+						"trust_all":       false,
+						"trust_allowlist": schema.NewSet(schema.HashString, []interface{}{"some-cluster"}),
+					},
+				}),
+			},
+			want: &models.ElasticsearchClusterTrustSettings{
+				Accounts: []*models.AccountTrustRelationship{{
+					AccountID:      ec.String("some-account"),
+					TrustAll:       ec.Bool(false),
+					TrustWhitelist: []string{"some-cluster"},
+				}},
+				External: []*models.ExternalTrustRelationship{},
+			},
+		},
+		{
+			name: "expands the external trust",
+			args: args{
+				external: schema.NewSet(hash, []interface{}{
+					map[string]interface{}{
+						"relationship_id": "some-relationship",
+						"trust_all":       true,
+						"trust_allowlist": schema.NewSet(schema.HashString, nil),
+					},
+				}),
+			},
+			want: &models.ElasticsearchClusterTrustSettings{
+				Accounts: []*models.AccountTrustRelationship{},
+				External: []*models.ExternalTrustRelationship{{
+					TrustRelationshipID: ec.String("some-relationship"),
+					TrustAll:            ec.Bool(true),
+					TrustWhitelist:      []string{},
+				}},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := expandTrust(tt.args.accounts, tt.args.external)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func Test_flattenTrust(t *testing.T) {
+	tests := []struct {
+		name         string
+		settings     *models.ElasticsearchClusterTrustSettings
+		wantAccounts []interface{}
+		wantExternal []interface{}
+	}{
+		{
+			name: "returns nil without trust settings",
+		},
+		{
+			name: "flattens the trust settings",
+			settings: &models.ElasticsearchClusterTrustSettings{
+				Accounts: []*models.AccountTrustRelationship{{
+					AccountID: ec.String("some-account"),
+					TrustAll:  ec.Bool(true),
+				}},
+				External: []*models.ExternalTrustRelationship{{
+					TrustRelationshipID: ec.String("some-relationship"),
+					TrustAll:            ec.Bool(false),
+					TrustWhitelist:      []string{"some-cluster"},
+				}},
+			},
+			wantAccounts: []interface{}{map[string]interface{}{
+				"account_id":      "some-account",
+				"trust_all":       true,
+				"trust_allowlist": []interface{}{},
+			}},
+			wantExternal: []interface{}{map[string]interface{}{
+				"relationship_id": "some-relationship",
+				"trust_all":       false,
+				"trust_allowlist": []interface{}{"some-cluster"},
+			}},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			accounts, external := flattenTrust(tt.settings)
+			assert.Equal(t, tt.wantAccounts, accounts)
+			assert.Equal(t, tt.wantExternal, external)
+		})
+	}
+}
+
+func TestResetTrust(t *testing.T) {
+	configured := &models.ElasticsearchClusterTrustSettings{
+		Accounts: []*models.AccountTrustRelationship{{
+			AccountID: ec.String("some-account"),
+			TrustAll:  ec.Bool(true),
+		}},
+	}
+	tests := []struct {
+		name         string
+		res          *models.ElasticsearchPayload
+		ownAccountID string
+		want         *models.ElasticsearchClusterTrustSettings
+	}{
+		{
+			name:         "restores the default trust of the own account",
+			res:          &models.ElasticsearchPayload{},
+			ownAccountID: "own-account",
+			want: &models.ElasticsearchClusterTrustSettings{
+				Accounts: []*models.AccountTrustRelationship{{
+					AccountID: ec.String("own-account"),
+					TrustAll:  ec.Bool(true),
+				}},
+				External: []*models.ExternalTrustRelationship{},
+			},
+		},
+		{
+			name: "sets empty trust settings when the own account isn't known",
+			res:  &models.ElasticsearchPayload{},
+			want: &models.ElasticsearchClusterTrustSettings{
+				Accounts: []*models.AccountTrustRelationship{},
+				External: []*models.ExternalTrustRelationship{},
+			},
+		},
+		{
+			name: "keeps the configured trust settings",
+			res: &models.ElasticsearchPayload{
+				Settings: &models.ElasticsearchClusterSettings{Trust: configured},
+			},
+			ownAccountID: "own-account",
+			want:         configured,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ResetTrust(tt.res, tt.ownAccountID)
+			assert.Equal(t, tt.want, tt.res.Settings.Trust)
+		})
+	}
+}
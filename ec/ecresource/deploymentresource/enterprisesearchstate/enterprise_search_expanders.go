@@ -18,9 +18,11 @@
 package enterprisesearchstate
 
 import (
+	"fmt"
 	"reflect"
 
 	"github.com/elastic/cloud-sdk-go/pkg/models"
+	"github.com/elastic/cloud-sdk-go/pkg/multierror"
 	"github.com/elastic/cloud-sdk-go/pkg/util/ec"
 
 	"github.com/elastic/terraform-provider-ec/ec/util"
@@ -33,14 +35,20 @@ func ExpandResources(ess []interface{}) ([]*models.EnterpriseSearchPayload, erro
 	}
 
 	result := make([]*models.EnterpriseSearchPayload, 0, len(ess))
+	var merr = multierror.NewPrefixed("invalid enterprise_search resource")
 	for _, raw := range ess {
 		resResource, err := expandResource(raw)
 		if err != nil {
-			return nil, err
+			merr = merr.Append(err)
+			continue
 		}
 		result = append(result, resResource)
 	}
 
+	if err := merr.ErrorOrNil(); err != nil {
+		return nil, err
+	}
+
 	return result, nil
 }
 
@@ -93,13 +101,15 @@ func expandResource(raw interface{}) (*models.EnterpriseSearchPayload, error) {
 func expandTopology(raw interface{}) ([]*models.EnterpriseSearchTopologyElement, error) {
 	var rawTopologies = raw.([]interface{})
 	var res = make([]*models.EnterpriseSearchTopologyElement, 0, len(rawTopologies))
-	for _, rawTop := range rawTopologies {
+	var merr = multierror.NewPrefixed("invalid enterprise_search resource")
+	for i, rawTop := range rawTopologies {
 		var topology = rawTop.(map[string]interface{})
 		var nodeType = parseNodeType(topology)
 
 		size, err := util.ParseTopologySize(topology)
 		if err != nil {
-			return nil, err
+			merr = merr.Append(fmt.Errorf("topology.%d: %w", i, err))
+			continue
 		}
 
 		var elem = models.EnterpriseSearchTopologyElement{
@@ -122,6 +132,10 @@ func expandTopology(raw interface{}) ([]*models.EnterpriseSearchTopologyElement,
 		res = append(res, &elem)
 	}
 
+	if err := merr.ErrorOrNil(); err != nil {
+		return nil, err
+	}
+
 	return res, nil
 }
 
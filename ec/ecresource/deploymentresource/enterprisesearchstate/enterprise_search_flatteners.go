@@ -84,8 +84,11 @@ func flattenTopology(plan *models.EnterpriseSearchPlan) []interface{} {
 			m["instance_configuration_id"] = topology.InstanceConfigurationID
 		}
 
-		if *topology.Size.Resource == "memory" {
+		// The size is kept in the memory_per_node notation regardless of
+		// the resource it applies to.
+		if topology.Size.Resource != nil {
 			m["memory_per_node"] = util.MemoryToState(*topology.Size.Value)
+			m["size_resource"] = *topology.Size.Resource
 		}
 
 		if nt := topology.NodeType; nt != nil {
@@ -111,6 +114,8 @@ func flattenTopology(plan *models.EnterpriseSearchPlan) []interface{} {
 		result = append(result, m)
 	}
 
+	util.SortTopology(result)
+
 	return result
 }
 
@@ -19,6 +19,7 @@ package deploymentresource
 
 import (
 	"github.com/elastic/cloud-sdk-go/pkg/models"
+	"github.com/elastic/cloud-sdk-go/pkg/multierror"
 	"github.com/elastic/cloud-sdk-go/pkg/util/ec"
 	"github.com/elastic/terraform-provider-ec/ec/ecresource/deploymentresource/apmstate"
 	"github.com/elastic/terraform-provider-ec/ec/ecresource/deploymentresource/deploymentstate"
@@ -28,90 +29,108 @@ import (
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 )
 
-func createResourceToModel(d *schema.ResourceData) (*models.DeploymentCreateRequest, error) {
+// ExpandCreateRequest expands the deployment resource data, which must match
+// the schema returned by NewSchema, into a deployment create request. The
+// topology elements keep the unset values, which the resource fills with the
+// deployment template defaults through ApplyTemplateDefaults.
+func ExpandCreateRequest(d *schema.ResourceData) (*models.DeploymentCreateRequest, error) {
+	resources, err := expandResources(d)
+	if err != nil {
+		return nil, err
+	}
+
 	var result = models.DeploymentCreateRequest{
 		Name: d.Get("name").(string),
 		Resources: &models.DeploymentCreateResources{
-			Apm:              make([]*models.ApmPayload, 0),
-			Elasticsearch:    make([]*models.ElasticsearchPayload, 0),
-			EnterpriseSearch: make([]*models.EnterpriseSearchPayload, 0),
-			Kibana:           make([]*models.KibanaPayload, 0),
+			Apm:              resources.Apm,
+			Elasticsearch:    resources.Elasticsearch,
+			EnterpriseSearch: resources.EnterpriseSearch,
+			Kibana:           resources.Kibana,
 		},
 	}
 
-	esRes, err := elasticsearchstate.ExpandResources(
-		d.Get("elasticsearch").([]interface{}),
-		d.Get("deployment_template_id").(string),
-	)
-	if err != nil {
-		return nil, err
+	// The snapshot source is only restored when the deployment is created,
+	// the expanded payloads are in the same order as the configured blocks.
+	for i, raw := range d.Get("elasticsearch").([]interface{}) {
+		if es, ok := raw.(map[string]interface{}); ok && i < len(resources.Elasticsearch) {
+			elasticsearchstate.ExpandSnapshotSource(es["snapshot_source"], resources.Elasticsearch[i])
+		}
 	}
-	result.Resources.Elasticsearch = append(result.Resources.Elasticsearch, esRes...)
 
-	kibanaRes, err := kibanastate.ExpandResources(d.Get("kibana").([]interface{}))
-	if err != nil {
-		return nil, err
-	}
-	result.Resources.Kibana = append(result.Resources.Kibana, kibanaRes...)
+	deploymentstate.ExpandTrafficFilterCreate(d.Get("traffic_filter").(*schema.Set), &result)
 
-	apmRes, err := apmstate.ExpandResources(d.Get("apm").([]interface{}))
-	if err != nil {
-		return nil, err
-	}
-	result.Resources.Apm = append(result.Resources.Apm, apmRes...)
+	return &result, nil
+}
 
-	enterpriseSearchRes, err := enterprisesearchstate.ExpandResources(d.Get("enterprise_search").([]interface{}))
+// ExpandUpdateRequest expands the deployment resource data, which must match
+// the schema returned by NewSchema, into a deployment update request. Orphaned
+// resources aren't pruned and, as with ExpandCreateRequest, the deployment
+// template defaults aren't applied. Neither is the default trust of the
+// deployments whose last trust block is removed, which is read from the API.
+func ExpandUpdateRequest(d *schema.ResourceData) (*models.DeploymentUpdateRequest, error) {
+	resources, err := expandResources(d)
 	if err != nil {
 		return nil, err
 	}
-	result.Resources.EnterpriseSearch = append(result.Resources.EnterpriseSearch, enterpriseSearchRes...)
-
-	deploymentstate.ExpandTrafficFilterCreate(d.Get("traffic_filter").(*schema.Set), &result)
 
-	return &result, nil
-}
-
-func updateResourceToModel(d *schema.ResourceData) (*models.DeploymentUpdateRequest, error) {
-	var result = models.DeploymentUpdateRequest{
+	return &models.DeploymentUpdateRequest{
 		Name: d.Get("name").(string),
 		// Setting this to false since we might not support all API resources in
 		// the provivider, setting to true, might cause some resources to be set
 		// incorrectly to "[]", which will cause the resources to be deleted.
 		PruneOrphans: ec.Bool(false),
-		Resources: &models.DeploymentUpdateResources{
-			Apm:              make([]*models.ApmPayload, 0),
-			Elasticsearch:    make([]*models.ElasticsearchPayload, 0),
-			EnterpriseSearch: make([]*models.EnterpriseSearchPayload, 0),
-			Kibana:           make([]*models.KibanaPayload, 0),
-		},
-	}
+		Resources:    resources,
+	}, nil
+}
+
+// expandResources expands the resource kinds shared by the create and update
+// requests. Each resource kind is read from the resource data once and the
+// expanded payloads are used as they are, rather than copied, since large
+// configurations are expanded on every apply.
+func expandResources(d *schema.ResourceData) (*models.DeploymentUpdateResources, error) {
+	// All the resource kinds are expanded before returning, so every invalid
+	// field is reported at once rather than only the first one.
+	var merr = multierror.NewPrefixed("invalid deployment configuration")
 
 	esRes, err := elasticsearchstate.ExpandResources(
 		d.Get("elasticsearch").([]interface{}),
 		d.Get("deployment_template_id").(string),
 	)
-	if err != nil {
-		return nil, err
-	}
-	result.Resources.Elasticsearch = append(result.Resources.Elasticsearch, esRes...)
+	merr = merr.Append(err)
 
 	kibanaRes, err := kibanastate.ExpandResources(d.Get("kibana").([]interface{}))
-	if err != nil {
-		return nil, err
-	}
-	result.Resources.Kibana = append(result.Resources.Kibana, kibanaRes...)
+	merr = merr.Append(err)
 
 	apmRes, err := apmstate.ExpandResources(d.Get("apm").([]interface{}))
-	if err != nil {
-		return nil, err
-	}
-	result.Resources.Apm = append(result.Resources.Apm, apmRes...)
+	merr = merr.Append(err)
 
 	enterpriseSearchRes, err := enterprisesearchstate.ExpandResources(d.Get("enterprise_search").([]interface{}))
-	if err != nil {
+	merr = merr.Append(err)
+
+	if err := merr.ErrorOrNil(); err != nil {
 		return nil, err
 	}
-	result.Resources.EnterpriseSearch = append(result.Resources.EnterpriseSearch, enterpriseSearchRes...)
+
+	// The API expects empty lists rather than null for the kinds which
+	// aren't configured.
+	var result = models.DeploymentUpdateResources{
+		Apm:              apmRes,
+		Elasticsearch:    esRes,
+		EnterpriseSearch: enterpriseSearchRes,
+		Kibana:           kibanaRes,
+	}
+	if result.Apm == nil {
+		result.Apm = make([]*models.ApmPayload, 0)
+	}
+	if result.Elasticsearch == nil {
+		result.Elasticsearch = make([]*models.ElasticsearchPayload, 0)
+	}
+	if result.EnterpriseSearch == nil {
+		result.EnterpriseSearch = make([]*models.EnterpriseSearchPayload, 0)
+	}
+	if result.Kibana == nil {
+		result.Kibana = make([]*models.KibanaPayload, 0)
+	}
 
 	return &result, nil
 }
@@ -18,20 +18,35 @@
 package deploymentresource
 
 import (
+	"errors"
 	"testing"
 
 	"github.com/elastic/cloud-sdk-go/pkg/api/mock"
 	"github.com/elastic/cloud-sdk-go/pkg/models"
+	"github.com/elastic/cloud-sdk-go/pkg/multierror"
 	"github.com/elastic/cloud-sdk-go/pkg/util/ec"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/stretchr/testify/assert"
 )
 
-func Test_createResourceToModel(t *testing.T) {
+func TestExpandCreateRequest(t *testing.T) {
 	deploymentRD := newResourceData(t, resDataParams{
 		ID:        mock.ValidClusterID,
 		Resources: newSampleDeployment(),
 	})
+	invalidES := newElasticsearchSample()
+	invalidES["topology"].([]interface{})[0].(map[string]interface{})["memory_per_node"] = "0.25g"
+	invalidKibana := newKibanaSample()
+	invalidKibana["topology"].([]interface{})[0].(map[string]interface{})["memory_per_node"] = "0.25g"
+	invalidRD := newResourceData(t, resDataParams{
+		ID: mock.ValidClusterID,
+		Resources: map[string]interface{}{
+			"name":                   "my_deployment_name",
+			"deployment_template_id": "aws-io-optimized",
+			"elasticsearch":          []interface{}{invalidES},
+			"kibana":                 []interface{}{invalidKibana},
+		},
+	})
 	type args struct {
 		d *schema.ResourceData
 	}
@@ -174,10 +189,18 @@ func Test_createResourceToModel(t *testing.T) {
 				},
 			},
 		},
+		{
+			name: "returns the errors of all the resources",
+			args: args{d: invalidRD},
+			err: multierror.NewPrefixed("invalid deployment configuration",
+				errors.New(`invalid elasticsearch resource: topology.0: size "0.25g" is invalid: minimum size is 0.5g`),
+				errors.New(`invalid kibana resource: topology.0: size "0.25g" is invalid: minimum size is 0.5g`),
+			),
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			got, err := createResourceToModel(tt.args.d)
+			got, err := ExpandCreateRequest(tt.args.d)
 			if tt.err != nil {
 				assert.EqualError(t, err, tt.err.Error())
 			} else {
@@ -188,7 +211,7 @@ func Test_createResourceToModel(t *testing.T) {
 	}
 }
 
-func Test_updateResourceToModel(t *testing.T) {
+func TestExpandUpdateRequest(t *testing.T) {
 	deploymentRD := newResourceData(t, resDataParams{
 		ID:        mock.ValidClusterID,
 		Resources: newSampleDeployment(),
@@ -334,7 +357,7 @@ func Test_updateResourceToModel(t *testing.T) {
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			got, err := updateResourceToModel(tt.args.d)
+			got, err := ExpandUpdateRequest(tt.args.d)
 			if tt.err != nil {
 				assert.EqualError(t, err, tt.err.Error())
 			} else {
@@ -344,3 +367,58 @@ func Test_updateResourceToModel(t *testing.T) {
 		})
 	}
 }
+
+func TestExpandUpdateRequestTrust(t *testing.T) {
+	withTrust := func() map[string]interface{} {
+		deployment := newSampleDeployment()
+		es := newElasticsearchSample()
+		es["trust_account"] = []interface{}{map[string]interface{}{
+			"account_id": "some-account",
+			"trust_all":  true,
+		}}
+		deployment["elasticsearch"] = []interface{}{es}
+		return deployment
+	}
+
+	unmanagedTrust := Resource().Data(newResourceData(t, resDataParams{
+		ID:        mock.ValidClusterID,
+		Resources: newSampleDeployment(),
+	}).State())
+
+	unchangedTrust := Resource().Data(newResourceData(t, resDataParams{
+		ID:        mock.ValidClusterID,
+		Resources: withTrust(),
+	}).State())
+
+	tests := []struct {
+		name string
+		d    *schema.ResourceData
+		want *models.ElasticsearchClusterTrustSettings
+	}{
+		{
+			name: "keeps the deployment trust settings when they're not managed",
+			d:    unmanagedTrust,
+		},
+		{
+			name: "sends the configured trust settings",
+			d:    unchangedTrust,
+			want: &models.ElasticsearchClusterTrustSettings{
+				Accounts: []*models.AccountTrustRelationship{{
+					AccountID:      ec.String("some-account"),
+					TrustAll:       ec.Bool(true),
+					TrustWhitelist: []string{},
+				}},
+				External: []*models.ExternalTrustRelationship{},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ExpandUpdateRequest(tt.d)
+			if !assert.NoError(t, err) {
+				return
+			}
+			assert.Equal(t, tt.want, got.Resources.Elasticsearch[0].Settings.Trust)
+		})
+	}
+}
@@ -0,0 +1,118 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package deploymentresource
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/elastic/cloud-sdk-go/pkg/api"
+	"github.com/elastic/cloud-sdk-go/pkg/client/extensions"
+	"github.com/elastic/cloud-sdk-go/pkg/models"
+	"github.com/elastic/cloud-sdk-go/pkg/multierror"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+
+	"github.com/elastic/terraform-provider-ec/ec/util"
+)
+
+// checkExtensions verifies that the extensions referenced by the elasticsearch
+// block are compatible with the deployment version, using the extensions
+// metadata, so the plan fails rather than the deployment plan failing halfway
+// through on the Elastic Cloud side.
+func checkExtensions(_ context.Context, d *schema.ResourceDiff, meta interface{}) error {
+	if d.Get("elasticsearch.#").(int) == 0 {
+		return nil
+	}
+
+	if !d.NewValueKnown("version") || !d.NewValueKnown("elasticsearch.0.extension") {
+		return nil
+	}
+
+	configured, ok := d.Get("elasticsearch.0.extension").(*schema.Set)
+	if !ok || configured.Len() == 0 {
+		return nil
+	}
+
+	client := meta.(*util.ProviderMeta).API
+	res, err := client.V1API.Extensions.ListExtensions(
+		extensions.NewListExtensionsParams(), client.AuthWriter,
+	)
+	if err != nil {
+		return multierror.NewPrefixed("failed listing extensions", api.UnwrapError(err))
+	}
+
+	return incompatibleExtensions(d.Get("version").(string), configured.List(), res.Payload.Extensions)
+}
+
+// incompatibleExtensions returns an error for each configured extension which
+// version doesn't match the deployment version, or which is an account
+// extension with a version constraint that doesn't match it.
+func incompatibleExtensions(version string, configured []interface{}, known []*models.Extension) error {
+	var byURL = make(map[string]*models.Extension, len(known))
+	for _, ext := range known {
+		if ext != nil && ext.URL != nil {
+			byURL[*ext.URL] = ext
+		}
+	}
+
+	var merr = multierror.NewPrefixed("incompatible elasticsearch extensions")
+	for _, raw := range configured {
+		var ext = raw.(map[string]interface{})
+		var name = ext["name"].(string)
+
+		if v := ext["version"].(string); v != version {
+			merr = merr.Append(fmt.Errorf(
+				`extension "%s": version %s doesn't match the deployment version %s`, name, v, version,
+			))
+			continue
+		}
+
+		accountExt, ok := byURL[ext["url"].(string)]
+		if !ok || accountExt.Version == nil {
+			continue
+		}
+
+		if !versionMatches(*accountExt.Version, version) {
+			merr = merr.Append(fmt.Errorf(
+				`extension "%s": the extension is compatible with version %s, not with the deployment version %s`,
+				name, *accountExt.Version, version,
+			))
+		}
+	}
+
+	return merr.ErrorOrNil()
+}
+
+// versionMatches returns true when the version matches the extension version
+// constraint, which is either an exact version or a version with wildcards
+// (e.g. "7.*").
+func versionMatches(constraint, version string) bool {
+	var cParts, vParts = strings.Split(constraint, "."), strings.Split(version, ".")
+	for i, c := range cParts {
+		if c == "*" {
+			return true
+		}
+
+		if i >= len(vParts) || c != vParts[i] {
+			return false
+		}
+	}
+
+	return len(cParts) == len(vParts)
+}
@@ -0,0 +1,111 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package deploymentresource
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/elastic/cloud-sdk-go/pkg/models"
+	"github.com/elastic/cloud-sdk-go/pkg/multierror"
+	"github.com/elastic/cloud-sdk-go/pkg/util/ec"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_incompatibleExtensions(t *testing.T) {
+	var known = []*models.Extension{
+		{Name: ec.String("synonyms"), URL: ec.String("repo://1"), Version: ec.String("7.*")},
+		{Name: ec.String("my-plugin"), URL: ec.String("repo://2"), Version: ec.String("7.9.1")},
+	}
+	var extension = func(name, version, url string) map[string]interface{} {
+		return map[string]interface{}{
+			"name": name, "type": "bundle", "version": version, "url": url,
+		}
+	}
+	type args struct {
+		version    string
+		configured []interface{}
+	}
+	tests := []struct {
+		name string
+		args args
+		err  error
+	}{
+		{
+			name: "returns no error when the extensions are compatible",
+			args: args{version: "7.10.1", configured: []interface{}{
+				extension("synonyms", "7.10.1", "repo://1"),
+			}},
+		},
+		{
+			name: "returns no error for extensions which aren't owned by the account",
+			args: args{version: "7.10.1", configured: []interface{}{
+				extension("external", "7.10.1", "repo://3"),
+			}},
+		},
+		{
+			name: "returns an error when the extension version doesn't match the deployment",
+			args: args{version: "7.10.1", configured: []interface{}{
+				extension("synonyms", "7.9.1", "repo://1"),
+			}},
+			err: multierror.NewPrefixed("incompatible elasticsearch extensions",
+				errors.New(`extension "synonyms": version 7.9.1 doesn't match the deployment version 7.10.1`),
+			),
+		},
+		{
+			name: "returns an error when the extension constraint doesn't match the deployment",
+			args: args{version: "7.10.1", configured: []interface{}{
+				extension("my-plugin", "7.10.1", "repo://2"),
+			}},
+			err: multierror.NewPrefixed("incompatible elasticsearch extensions",
+				errors.New(`extension "my-plugin": the extension is compatible with version 7.9.1, not with the deployment version 7.10.1`),
+			),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := incompatibleExtensions(tt.args.version, tt.args.configured, known)
+			if tt.err != nil {
+				assert.EqualError(t, err, tt.err.Error())
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
+
+func Test_versionMatches(t *testing.T) {
+	tests := []struct {
+		constraint string
+		version    string
+		want       bool
+	}{
+		{constraint: "7.10.1", version: "7.10.1", want: true},
+		{constraint: "7.*", version: "7.10.1", want: true},
+		{constraint: "7.10.*", version: "7.10.1", want: true},
+		{constraint: "*", version: "8.0.0", want: true},
+		{constraint: "7.9.1", version: "7.10.1"},
+		{constraint: "6.*", version: "7.10.1"},
+		{constraint: "7.10", version: "7.10.1"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.constraint+" "+tt.version, func(t *testing.T) {
+			assert.Equal(t, tt.want, versionMatches(tt.constraint, tt.version))
+		})
+	}
+}
@@ -29,10 +29,13 @@ import (
 	"github.com/elastic/terraform-provider-ec/ec/ecresource/deploymentresource/elasticsearchstate"
 	"github.com/elastic/terraform-provider-ec/ec/ecresource/deploymentresource/enterprisesearchstate"
 	"github.com/elastic/terraform-provider-ec/ec/ecresource/deploymentresource/kibanastate"
+	"github.com/elastic/terraform-provider-ec/ec/util"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 )
 
-func modelToState(d *schema.ResourceData, res *models.DeploymentGetResponse) error {
+// FlattenDeployment flattens the deployment API response into the resource
+// data, which must match the schema returned by NewSchema.
+func FlattenDeployment(d *schema.ResourceData, res *models.DeploymentGetResponse) error {
 	if err := d.Set("name", res.Name); err != nil {
 		return err
 	}
@@ -47,22 +50,34 @@ func modelToState(d *schema.ResourceData, res *models.DeploymentGetResponse) err
 			return err
 		}
 
+		if v := getDeploymentVersion(res.Resources); v != "" {
+			if err := d.Set("version", v); err != nil {
+				return err
+			}
+		}
+
 		esFlattened := elasticsearchstate.FlattenResources(res.Resources.Elasticsearch, *res.Name)
+		orderTopologyLikeState(d, "elasticsearch", esFlattened)
+		keepSnapshotSource(d, esFlattened)
+		dropUnmanagedTrust(d, esFlattened)
 		if err := d.Set("elasticsearch", esFlattened); err != nil {
 			return err
 		}
 
 		kibanaFlattened := kibanastate.FlattenResources(res.Resources.Kibana, *res.Name)
+		orderTopologyLikeState(d, "kibana", kibanaFlattened)
 		if err := d.Set("kibana", kibanaFlattened); err != nil {
 			return err
 		}
 
 		apmFlattened := apmstate.FlattenResources(res.Resources.Apm, *res.Name)
+		orderTopologyLikeState(d, "apm", apmFlattened)
 		if err := d.Set("apm", apmFlattened); err != nil {
 			return err
 		}
 
 		enterpriseSearchFlattened := enterprisesearchstate.FlattenResources(res.Resources.EnterpriseSearch, *res.Name)
+		orderTopologyLikeState(d, "enterprise_search", enterpriseSearchFlattened)
 		if err := d.Set("enterprise_search", enterpriseSearchFlattened); err != nil {
 			return err
 		}
@@ -77,6 +92,57 @@ func modelToState(d *schema.ResourceData, res *models.DeploymentGetResponse) err
 	return nil
 }
 
+// orderTopologyLikeState orders the topology elements of the flattened resource
+// kind like the ones in the current state, so the topology elements order set
+// in the configuration is preserved no matter the order returned by the API.
+func orderTopologyLikeState(d *schema.ResourceData, kind string, flattened []interface{}) {
+	if len(flattened) == 0 || d.Get(kind+".#").(int) == 0 {
+		return
+	}
+
+	// Only the topology is read from the state, rather than the whole block.
+	priorTopology, _ := d.Get(kind + ".0.topology").([]interface{})
+	if m, ok := flattened[0].(map[string]interface{}); ok {
+		if topology, ok := m["topology"].([]interface{}); ok {
+			m["topology"] = util.OrderTopologyLike(priorTopology, topology)
+		}
+	}
+}
+
+// keepSnapshotSource keeps the elasticsearch snapshot_source from the current
+// state, since the restore settings are transient and not returned by the API.
+func keepSnapshotSource(d *schema.ResourceData, flattened []interface{}) {
+	if len(flattened) == 0 || d.Get("elasticsearch.#").(int) == 0 {
+		return
+	}
+
+	source, _ := d.Get("elasticsearch.0.snapshot_source").([]interface{})
+	if m, ok := flattened[0].(map[string]interface{}); ok && len(source) > 0 {
+		m["snapshot_source"] = source
+	}
+}
+
+// dropUnmanagedTrust removes the trust settings from the flattened
+// elasticsearch block when the current state has none, since the deployment's
+// trust is only managed once a trust_account or trust_external is set, e.g. the
+// default trust of the deployment's own account isn't shown as a change.
+func dropUnmanagedTrust(d *schema.ResourceData, flattened []interface{}) {
+	if len(flattened) == 0 {
+		return
+	}
+
+	accounts, _ := d.Get("elasticsearch.0.trust_account").(*schema.Set)
+	external, _ := d.Get("elasticsearch.0.trust_external").(*schema.Set)
+	if (accounts != nil && accounts.Len() > 0) || (external != nil && external.Len() > 0) {
+		return
+	}
+
+	if m, ok := flattened[0].(map[string]interface{}); ok {
+		m["trust_account"] = []interface{}{}
+		m["trust_external"] = []interface{}{}
+	}
+}
+
 func getDeploymentTemplateID(res *models.DeploymentResources) (string, error) {
 	var deploymentTemplateID string
 	var foundTemplates []string
@@ -28,7 +28,7 @@ import (
 	"github.com/stretchr/testify/assert"
 )
 
-func Test_modelToState(t *testing.T) {
+func TestFlattenDeployment(t *testing.T) {
 	deploymentSchemaArg := schema.TestResourceDataRaw(t, NewSchema(), nil)
 	deploymentSchemaArg.SetId(mock.ValidClusterID)
 
@@ -36,6 +36,10 @@ func Test_modelToState(t *testing.T) {
 		ID:        mock.ValidClusterID,
 		Resources: newSampleDeployment(),
 	})
+	// The deployment version is read from the running resources.
+	if err := wantDeployment.Set("version", "7.7.0"); err != nil {
+		t.Fatal(err)
+	}
 
 	type args struct {
 		d   *schema.ResourceData
@@ -215,7 +219,7 @@ func Test_modelToState(t *testing.T) {
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			err := modelToState(tt.args.d, tt.args.res)
+			err := FlattenDeployment(tt.args.d, tt.args.res)
 			if tt.err != nil {
 				assert.EqualError(t, err, tt.err.Error())
 			} else {
@@ -0,0 +1,90 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package deploymentresource
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/elastic/cloud-sdk-go/pkg/api"
+	"github.com/elastic/cloud-sdk-go/pkg/api/deploymentapi/deputil"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+
+	"github.com/elastic/terraform-provider-ec/ec/util"
+)
+
+// healthyPollFrequency is the time to wait between the checks of the
+// deployment's health.
+var healthyPollFrequency = 10 * time.Second
+
+// waitForHealthy waits until the deployment reports itself as healthy or the
+// context is done. It's used to withhold the endpoints and credentials until
+// the deployment can serve requests, so the resources which depend on them
+// don't race a deployment which is still initializing.
+func waitForHealthy(ctx context.Context, client *api.API, id string) error {
+	for {
+		res, err := util.GetDeployment(client, id, deputil.QueryParams{})
+		if err != nil {
+			return err
+		}
+
+		if res.Healthy != nil && *res.Healthy {
+			return nil
+		}
+
+		select {
+		case <-ctx.Done():
+			return fmt.Errorf("deployment didn't become healthy: %w", ctx.Err())
+		case <-time.After(healthyPollFrequency):
+		}
+	}
+}
+
+// awaitHealthy waits for the deployment to be healthy when "wait_for_healthy"
+// is set. When it doesn't become healthy, an error is returned rather than a
+// warning, so the resources which depend on its endpoints aren't applied with
+// empty values. The deployment ID and credentials are kept in the state, so a
+// deployment which is being created is tainted rather than orphaned.
+func awaitHealthy(ctx context.Context, d *schema.ResourceData, providerMeta *util.ProviderMeta) diag.Diagnostics {
+	if !d.Get("wait_for_healthy").(bool) {
+		return nil
+	}
+
+	if err := waitForHealthy(ctx, providerMeta.API, d.Id()); err != nil {
+		if err := d.Set("console_url", providerMeta.DeploymentConsoleURL(d.Id())); err != nil {
+			return diag.FromErr(err)
+		}
+		return newUnhealthyError(d.Id(), err)
+	}
+
+	return nil
+}
+
+// newUnhealthyError returns the error reporting that the deployment didn't
+// become healthy.
+func newUnhealthyError(id string, err error) diag.Diagnostics {
+	return diag.Diagnostics{{
+		Severity: diag.Error,
+		Summary:  fmt.Sprintf("deployment %s: failed waiting for the deployment to be healthy: %s", id, err),
+		Detail: "The deployment's endpoints haven't been set since it isn't healthy yet, so the resources " +
+			"which depend on them haven't been applied. The deployment and its credentials are kept in the state, " +
+			"a deployment which was being created is marked as tainted.",
+	}}
+}
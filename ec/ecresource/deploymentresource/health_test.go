@@ -0,0 +1,101 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package deploymentresource
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/elastic/cloud-sdk-go/pkg/api"
+	"github.com/elastic/cloud-sdk-go/pkg/api/mock"
+	"github.com/elastic/cloud-sdk-go/pkg/models"
+	"github.com/elastic/cloud-sdk-go/pkg/util/ec"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/elastic/terraform-provider-ec/ec/util"
+)
+
+func Test_waitForHealthy(t *testing.T) {
+	healthyPollFrequency = 0
+	unhealthy := &models.DeploymentGetResponse{ID: &mock.ValidClusterID, Healthy: ec.Bool(false)}
+	healthy := &models.DeploymentGetResponse{ID: &mock.ValidClusterID, Healthy: ec.Bool(true)}
+
+	t.Run("waits until the deployment is healthy", func(t *testing.T) {
+		client := api.NewMock(
+			mock.New200StructResponse(unhealthy),
+			mock.New200StructResponse(healthy),
+		)
+		assert.NoError(t, waitForHealthy(context.Background(), client, mock.ValidClusterID))
+	})
+
+	t.Run("stops waiting when the context is done", func(t *testing.T) {
+		healthyPollFrequency = time.Hour
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+
+		client := api.NewMock(mock.New200StructResponse(unhealthy))
+		assert.EqualError(t,
+			waitForHealthy(ctx, client, mock.ValidClusterID),
+			"deployment didn't become healthy: context canceled",
+		)
+	})
+}
+
+func Test_awaitHealthy(t *testing.T) {
+	healthyPollFrequency = time.Hour
+	unhealthy := &models.DeploymentGetResponse{ID: &mock.ValidClusterID, Healthy: ec.Bool(false)}
+
+	newDeployment := func(waitForHealthy bool) *schema.ResourceData {
+		raw := newSampleDeployment()
+		raw["wait_for_healthy"] = waitForHealthy
+		raw["elasticsearch_username"] = "my-username"
+		raw["elasticsearch_password"] = "my-password"
+		return newResourceData(t, resDataParams{ID: mock.ValidClusterID, Resources: raw})
+	}
+
+	t.Run("doesn't wait when wait_for_healthy isn't set", func(t *testing.T) {
+		providerMeta := util.NewProviderMeta(api.NewMock(), "")
+		assert.Nil(t, awaitHealthy(context.Background(), newDeployment(false), providerMeta))
+	})
+
+	t.Run("returns an error and keeps the deployment when it isn't healthy before the timeout", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+
+		d := newDeployment(true)
+		providerMeta := util.NewProviderMeta(
+			api.NewMock(mock.New200StructResponse(unhealthy)), "https://ece.example.com",
+		)
+		got := awaitHealthy(ctx, d, providerMeta)
+
+		assert.True(t, got.HasError())
+		assert.Len(t, got, 1)
+		assert.Equal(t, diag.Error, got[0].Severity)
+		assert.Equal(t,
+			"deployment "+mock.ValidClusterID+": failed waiting for the deployment to be healthy: deployment didn't become healthy: context canceled",
+			got[0].Summary,
+		)
+		assert.Equal(t, mock.ValidClusterID, d.Id())
+		assert.Equal(t, "my-username", d.Get("elasticsearch_username"))
+		assert.Equal(t, "my-password", d.Get("elasticsearch_password"))
+		assert.Equal(t, "https://ece.example.com/deployments/"+mock.ValidClusterID, d.Get("console_url"))
+	})
+}
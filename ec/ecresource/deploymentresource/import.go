@@ -0,0 +1,122 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package deploymentresource
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/elastic/cloud-sdk-go/pkg/api/deploymentapi"
+	"github.com/elastic/cloud-sdk-go/pkg/models"
+	"github.com/elastic/cloud-sdk-go/pkg/multierror"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+
+	"github.com/elastic/terraform-provider-ec/ec/util"
+)
+
+const (
+	importByName = "name"
+
+	// importSearchSize is the maximum number of deployments listed when more
+	// than one deployment has the imported name.
+	importSearchSize = 100
+)
+
+// importFunc allows a deployment to be imported by its ID or by its name with
+// the "name=<name>" selector. When the selector is used, the deployment ID is
+// resolved via the deployments search API.
+func importFunc(_ context.Context, d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
+	name, ok := parseImportSelector(d.Id())
+	if !ok {
+		return []*schema.ResourceData{d}, nil
+	}
+
+	client := meta.(*util.ProviderMeta).API
+	res, err := deploymentapi.Search(deploymentapi.SearchParams{
+		API: client, Request: newImportSearchRequest(name),
+	})
+	if err != nil {
+		return nil, multierror.NewPrefixed(
+			fmt.Sprintf(`failed searching deployments with name "%s"`, name), err,
+		)
+	}
+
+	id, err := resolveImportID(name, res)
+	if err != nil {
+		return nil, err
+	}
+
+	d.SetId(id)
+	return []*schema.ResourceData{d}, nil
+}
+
+// parseImportSelector parses an import ID in the "name=<name>" form. Returns
+// false when the ID isn't a selector.
+func parseImportSelector(id string) (string, bool) {
+	parts := strings.SplitN(id, "=", 2)
+	if len(parts) != 2 || parts[0] != importByName || parts[1] == "" {
+		return "", false
+	}
+
+	return parts[1], true
+}
+
+// newImportSearchRequest matches the exact deployment name through the
+// non-analyzed keyword field.
+func newImportSearchRequest(name string) *models.SearchRequest {
+	return &models.SearchRequest{
+		Size: importSearchSize,
+		Query: &models.QueryContainer{
+			Term: map[string]models.TermQuery{
+				"name.keyword": {Value: name},
+			},
+		},
+	}
+}
+
+// resolveImportID obtains the deployment ID from the search response. An
+// error listing all the candidates is returned when the result is ambiguous.
+func resolveImportID(name string, res *models.DeploymentsSearchResponse) (string, error) {
+	var candidates []*models.DeploymentSearchResponse
+	for _, dep := range res.Deployments {
+		if dep.ID != nil {
+			candidates = append(candidates, dep)
+		}
+	}
+
+	if len(candidates) == 0 {
+		return "", fmt.Errorf(`no deployment found with name "%s"`, name)
+	}
+
+	if len(candidates) > 1 {
+		var found = make([]string, 0, len(candidates))
+		for _, dep := range candidates {
+			found = append(found, *dep.ID)
+		}
+		sort.Strings(found)
+
+		return "", fmt.Errorf(
+			`found %d deployments with name "%s", please import the deployment by id: %s`,
+			len(candidates), name, strings.Join(found, ", "),
+		)
+	}
+
+	return *candidates[0].ID, nil
+}
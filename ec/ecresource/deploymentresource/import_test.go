@@ -0,0 +1,123 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package deploymentresource
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/elastic/cloud-sdk-go/pkg/models"
+	"github.com/elastic/cloud-sdk-go/pkg/util/ec"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_parseImportSelector(t *testing.T) {
+	tests := []struct {
+		name   string
+		id     string
+		want   string
+		wantOk bool
+	}{
+		{
+			name: "deployment id is not a selector",
+			id:   "320b7b540dfc967a7a649c18e2fce4ed",
+		},
+		{
+			name:   "parses a name selector",
+			id:     "name=my deployment",
+			want:   "my deployment",
+			wantOk: true,
+		},
+		{
+			name:   "parses a name selector with an equal sign in the value",
+			id:     "name=my=deployment",
+			want:   "my=deployment",
+			wantOk: true,
+		},
+		{
+			name: "unknown selector key",
+			id:   "alias=my-alias",
+		},
+		{
+			name: "empty selector value",
+			id:   "name=",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, ok := parseImportSelector(tt.id)
+			assert.Equal(t, tt.want, got)
+			assert.Equal(t, tt.wantOk, ok)
+		})
+	}
+}
+
+func Test_newImportSearchRequest(t *testing.T) {
+	want := &models.SearchRequest{
+		Size: 100,
+		Query: &models.QueryContainer{
+			Term: map[string]models.TermQuery{
+				"name.keyword": {Value: "my deployment"},
+			},
+		},
+	}
+	assert.Equal(t, want, newImportSearchRequest("my deployment"))
+}
+
+func Test_resolveImportID(t *testing.T) {
+	tests := []struct {
+		name string
+		res  *models.DeploymentsSearchResponse
+		want string
+		err  error
+	}{
+		{
+			name: "returns an error when no deployments are found",
+			res:  &models.DeploymentsSearchResponse{},
+			err:  errors.New(`no deployment found with name "my deployment"`),
+		},
+		{
+			name: "resolves the deployment id",
+			res: &models.DeploymentsSearchResponse{Deployments: []*models.DeploymentSearchResponse{
+				{ID: ec.String("320b7b540dfc967a7a649c18e2fce4ed"), Name: ec.String("my deployment")},
+			}},
+			want: "320b7b540dfc967a7a649c18e2fce4ed",
+		},
+		{
+			name: "returns the candidates when the result is ambiguous",
+			res: &models.DeploymentsSearchResponse{Deployments: []*models.DeploymentSearchResponse{
+				{ID: ec.String("320b7b540dfc967a7a649c18e2fce4ed"), Name: ec.String("my deployment")},
+				{ID: ec.String("1fcd7e1d3e4f4c7c8a3b8b3e2d1b1f8a"), Name: ec.String("my deployment")},
+			}},
+			err: errors.New(`found 2 deployments with name "my deployment", please import the deployment by id: ` +
+				`1fcd7e1d3e4f4c7c8a3b8b3e2d1b1f8a, 320b7b540dfc967a7a649c18e2fce4ed`,
+			),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := resolveImportID("my deployment", tt.res)
+			if tt.err != nil {
+				assert.EqualError(t, err, tt.err.Error())
+			} else {
+				assert.NoError(t, err)
+			}
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
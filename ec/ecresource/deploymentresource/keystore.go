@@ -0,0 +1,134 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package deploymentresource
+
+import (
+	"github.com/elastic/cloud-sdk-go/pkg/api"
+	"github.com/elastic/cloud-sdk-go/pkg/api/deploymentapi/eskeystoreapi"
+	"github.com/elastic/cloud-sdk-go/pkg/models"
+	"github.com/elastic/cloud-sdk-go/pkg/multierror"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+
+	"github.com/elastic/terraform-provider-ec/ec/util"
+)
+
+const keystoreContentsKey = "elasticsearch.0.keystore_contents"
+
+// handleKeystoreChange writes the changes to the elasticsearch
+// keystore_contents to the deployment's Elasticsearch keystore, through a
+// single keystore update.
+func handleKeystoreChange(d *schema.ResourceData, client *api.API) error {
+	if !d.HasChange(keystoreContentsKey) {
+		return nil
+	}
+
+	old, new := d.GetChange(keystoreContentsKey)
+	contents := expandKeystoreContents(toStringMap(old), toStringMap(new))
+	if contents == nil {
+		return nil
+	}
+
+	if err := util.UpdateKeystore(client, d.Id(), contents); err != nil {
+		return multierror.NewPrefixed("failed updating the elasticsearch keystore", err)
+	}
+
+	return nil
+}
+
+// expandKeystoreContents returns the partial keystore contents which set the
+// added and changed settings, and remove the ones which are no longer set, or
+// nil when there's nothing to update.
+func expandKeystoreContents(old, new map[string]string) *util.KeystoreUpdate {
+	var secrets = make(map[string]*models.KeystoreSecret)
+	for name, value := range new {
+		if prior, ok := old[name]; !ok || prior != value {
+			secrets[name] = &models.KeystoreSecret{Value: value}
+		}
+	}
+
+	// Since the keystore is updated through a partial payload, the settings
+	// which are no longer set are removed with a null secret.
+	for name := range old {
+		if _, ok := new[name]; !ok {
+			secrets[name] = nil
+		}
+	}
+
+	if len(secrets) == 0 {
+		return nil
+	}
+
+	return &util.KeystoreUpdate{Secrets: secrets}
+}
+
+// readKeystoreContents refreshes the elasticsearch keystore_contents. Since the
+// keystore values are never returned by the API, the prior values are kept for
+// the settings which are still present in the keystore, and the ones removed
+// outside of terraform are dropped so they're written on the next apply.
+func readKeystoreContents(d *schema.ResourceData, client *api.API, prior map[string]string) error {
+	if len(prior) == 0 {
+		return nil
+	}
+
+	res, err := eskeystoreapi.Get(eskeystoreapi.GetParams{
+		API:          client,
+		DeploymentID: d.Id(),
+	})
+	if err != nil {
+		return multierror.NewPrefixed("failed reading the elasticsearch keystore", err)
+	}
+
+	es, _ := d.Get("elasticsearch").([]interface{})
+	if len(es) == 0 {
+		return nil
+	}
+
+	if m, ok := es[0].(map[string]interface{}); ok {
+		m["keystore_contents"] = keepRemoteKeystoreContents(prior, res)
+	}
+
+	return d.Set("elasticsearch", es)
+}
+
+// keepRemoteKeystoreContents returns the prior settings which are present in
+// the remote keystore.
+func keepRemoteKeystoreContents(prior map[string]string, remote *models.KeystoreContents) map[string]interface{} {
+	var result = make(map[string]interface{})
+	if remote == nil {
+		return result
+	}
+
+	for name, value := range prior {
+		if _, ok := remote.Secrets[name]; ok {
+			result[name] = value
+		}
+	}
+
+	return result
+}
+
+func toStringMap(v interface{}) map[string]string {
+	m, _ := v.(map[string]interface{})
+	var result = make(map[string]string, len(m))
+	for k, v := range m {
+		if s, ok := v.(string); ok {
+			result[k] = s
+		}
+	}
+	return result
+}
@@ -0,0 +1,133 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package deploymentresource
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/elastic/cloud-sdk-go/pkg/models"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/elastic/terraform-provider-ec/ec/util"
+)
+
+func Test_expandKeystoreContents(t *testing.T) {
+	type args struct {
+		old map[string]string
+		new map[string]string
+	}
+	tests := []struct {
+		name string
+		args args
+		want *util.KeystoreUpdate
+		// wantJSON is the request body sent to the keystore API.
+		wantJSON string
+	}{
+		{
+			name: "returns nil when there are no changes",
+			args: args{
+				old: map[string]string{"some.setting": "value"},
+				new: map[string]string{"some.setting": "value"},
+			},
+		},
+		{
+			name: "sets the added settings",
+			args: args{
+				new: map[string]string{
+					"s3.client.default.access_key": "access",
+					"s3.client.default.secret_key": "secret",
+				},
+			},
+			want: &util.KeystoreUpdate{Secrets: map[string]*models.KeystoreSecret{
+				"s3.client.default.access_key": {Value: "access"},
+				"s3.client.default.secret_key": {Value: "secret"},
+			}},
+		},
+		{
+			name: "sets the changed settings and removes the deleted ones",
+			args: args{
+				old: map[string]string{
+					"s3.client.default.access_key": "access",
+					"s3.client.default.secret_key": "secret",
+					"some.setting":                 "value",
+				},
+				new: map[string]string{
+					"s3.client.default.access_key": "access",
+					"s3.client.default.secret_key": "rotated",
+				},
+			},
+			want: &util.KeystoreUpdate{Secrets: map[string]*models.KeystoreSecret{
+				"s3.client.default.secret_key": {Value: "rotated"},
+				"some.setting":                 nil,
+			}},
+			wantJSON: `{"secrets":{"s3.client.default.secret_key":{"value":"rotated"},"some.setting":null}}`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := expandKeystoreContents(tt.args.old, tt.args.new)
+			assert.Equal(t, tt.want, got)
+			if tt.wantJSON != "" {
+				body, err := json.Marshal(got)
+				assert.NoError(t, err)
+				assert.JSONEq(t, tt.wantJSON, string(body))
+			}
+		})
+	}
+}
+
+func Test_keepRemoteKeystoreContents(t *testing.T) {
+	type args struct {
+		prior  map[string]string
+		remote *models.KeystoreContents
+	}
+	tests := []struct {
+		name string
+		args args
+		want map[string]interface{}
+	}{
+		{
+			name: "drops all the settings on an empty response",
+			args: args{prior: map[string]string{"some.setting": "value"}},
+			want: map[string]interface{}{},
+		},
+		{
+			name: "keeps the settings present in the keystore",
+			args: args{
+				prior: map[string]string{
+					"s3.client.default.access_key": "access",
+					"some.setting":                 "value",
+				},
+				remote: &models.KeystoreContents{Secrets: map[string]models.KeystoreSecret{
+					"s3.client.default.access_key": {},
+					"other.setting":                {},
+				}},
+			},
+			want: map[string]interface{}{
+				"s3.client.default.access_key": "access",
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := keepRemoteKeystoreContents(tt.args.prior, tt.args.remote)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
@@ -18,9 +18,11 @@
 package kibanastate
 
 import (
+	"fmt"
 	"reflect"
 
 	"github.com/elastic/cloud-sdk-go/pkg/models"
+	"github.com/elastic/cloud-sdk-go/pkg/multierror"
 	"github.com/elastic/cloud-sdk-go/pkg/util/ec"
 
 	"github.com/elastic/terraform-provider-ec/ec/util"
@@ -33,14 +35,20 @@ func ExpandResources(kibanas []interface{}) ([]*models.KibanaPayload, error) {
 	}
 
 	result := make([]*models.KibanaPayload, 0, len(kibanas))
+	var merr = multierror.NewPrefixed("invalid kibana resource")
 	for _, raw := range kibanas {
 		resResource, err := expandResource(raw)
 		if err != nil {
-			return nil, err
+			merr = merr.Append(err)
+			continue
 		}
 		result = append(result, resResource)
 	}
 
+	if err := merr.ErrorOrNil(); err != nil {
+		return nil, err
+	}
+
 	return result, nil
 }
 
@@ -93,12 +101,14 @@ func expandResource(raw interface{}) (*models.KibanaPayload, error) {
 func expandTopology(raw interface{}) ([]*models.KibanaClusterTopologyElement, error) {
 	var rawTopologies = raw.([]interface{})
 	var res = make([]*models.KibanaClusterTopologyElement, 0, len(rawTopologies))
-	for _, rawTop := range rawTopologies {
+	var merr = multierror.NewPrefixed("invalid kibana resource")
+	for i, rawTop := range rawTopologies {
 		var topology = rawTop.(map[string]interface{})
 
 		size, err := util.ParseTopologySize(topology)
 		if err != nil {
-			return nil, err
+			merr = merr.Append(fmt.Errorf("topology.%d: %w", i, err))
+			continue
 		}
 
 		var elem = models.KibanaClusterTopologyElement{
@@ -124,6 +134,10 @@ func expandTopology(raw interface{}) ([]*models.KibanaClusterTopologyElement, er
 		res = append(res, &elem)
 	}
 
+	if err := merr.ErrorOrNil(); err != nil {
+		return nil, err
+	}
+
 	return res, nil
 }
 
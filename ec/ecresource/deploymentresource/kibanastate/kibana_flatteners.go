@@ -87,8 +87,11 @@ func flattenKibanaTopology(plan *models.KibanaClusterPlan) []interface{} {
 		// 	m["memory_per_node"] = strconv.Itoa(int(topology.MemoryPerNode))
 		// }
 
-		if *topology.Size.Resource == "memory" {
+		// The size is kept in the memory_per_node notation regardless of
+		// the resource it applies to.
+		if topology.Size.Resource != nil {
 			m["memory_per_node"] = util.MemoryToState(*topology.Size.Value)
+			m["size_resource"] = *topology.Size.Resource
 		}
 
 		if topology.NodeCountPerZone > 0 {
@@ -104,6 +107,8 @@ func flattenKibanaTopology(plan *models.KibanaClusterPlan) []interface{} {
 		result = append(result, m)
 	}
 
+	util.SortTopology(result)
+
 	return result
 }
 
@@ -151,6 +151,7 @@ func TestFlattenResources(t *testing.T) {
 						map[string]interface{}{
 							"instance_configuration_id": "aws.kibana.r4",
 							"memory_per_node":           "1g",
+							"size_resource":             "memory",
 							"zone_count":                int32(1),
 						},
 					},
@@ -178,6 +179,7 @@ func TestFlattenResources(t *testing.T) {
 						}},
 						"instance_configuration_id": "aws.kibana.r4",
 						"memory_per_node":           "1g",
+						"size_resource":             "memory",
 						"zone_count":                int32(1),
 					}},
 				},
@@ -0,0 +1,68 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package deploymentresource
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"log"
+	"os"
+	"path/filepath"
+	"time"
+)
+
+// payloadDumpDirEnv is the environment variable which, when set, makes the
+// provider write the deployment create and update payloads to that directory.
+const payloadDumpDirEnv = "EC_PAYLOAD_DUMP_DIR"
+
+// dumpPayload writes the JSON encoded payload to a file in the directory set
+// in the EC_PAYLOAD_DUMP_DIR environment variable, so it can be attached to a
+// support case or compared with the API documentation. Since it's a debugging
+// aid, failures are logged rather than returned.
+func dumpPayload(operation, name string, payload interface{}) {
+	dir := os.Getenv(payloadDumpDirEnv)
+	if dir == "" {
+		return
+	}
+
+	path, err := writePayload(dir, operation, name, payload, time.Now())
+	if err != nil {
+		log.Printf("[WARN] failed writing the deployment %s payload: %s", operation, err)
+		return
+	}
+
+	log.Printf("[INFO] deployment %s payload written to %s", operation, path)
+}
+
+func writePayload(dir, operation, name string, payload interface{}, now time.Time) (string, error) {
+	b, err := json.MarshalIndent(payload, "", "  ")
+	if err != nil {
+		return "", err
+	}
+
+	if err := os.MkdirAll(dir, 0700); err != nil {
+		return "", err
+	}
+
+	path := filepath.Join(dir, fmt.Sprintf("%s-%s-%d.json",
+		name, operation, now.UnixNano(),
+	))
+
+	return path, ioutil.WriteFile(path, b, 0600)
+}
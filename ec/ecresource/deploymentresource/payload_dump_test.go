@@ -0,0 +1,48 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package deploymentresource
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/elastic/cloud-sdk-go/pkg/models"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_writePayload(t *testing.T) {
+	dir, err := ioutil.TempDir("", "payload")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	var now = time.Unix(0, 1600000000000000000)
+	path, err := writePayload(filepath.Join(dir, "dumps"), "create", "my_deployment",
+		&models.DeploymentCreateRequest{Name: "my_deployment"}, now,
+	)
+	assert.NoError(t, err)
+	assert.Equal(t, filepath.Join(dir, "dumps", "my_deployment-create-1600000000000000000.json"), path)
+
+	b, err := ioutil.ReadFile(path)
+	assert.NoError(t, err)
+	assert.Equal(t, "{\n  \"name\": \"my_deployment\",\n  \"resources\": null\n}", string(b))
+}
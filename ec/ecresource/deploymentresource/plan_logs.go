@@ -0,0 +1,140 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package deploymentresource
+
+import (
+	"fmt"
+
+	"github.com/elastic/cloud-sdk-go/pkg/api"
+	"github.com/elastic/cloud-sdk-go/pkg/api/deploymentapi"
+	"github.com/elastic/cloud-sdk-go/pkg/api/deploymentapi/deputil"
+	"github.com/elastic/cloud-sdk-go/pkg/models"
+	"github.com/elastic/cloud-sdk-go/pkg/util"
+)
+
+const (
+	// maxPlanLogMessages is the maximum number of log messages included for
+	// each failed plan step.
+	maxPlanLogMessages = 5
+
+	// maxPlanLogMessageLength is the length after which each log message is
+	// truncated.
+	maxPlanLogMessageLength = 300
+)
+
+type resourcePlanLog struct {
+	kind  string
+	refID string
+	steps []*models.ClusterPlanStepInfo
+}
+
+// planFailureLogs obtains the log messages of the failed plan steps of all the
+// deployment resources, so the cause of a failed plan (i.e. "not enough
+// capacity") can be surfaced in the error. Since it's only used to enrich an
+// existing error, any failure obtaining the logs is ignored.
+func planFailureLogs(client *api.API, id string) []error {
+	res, err := deploymentapi.Get(deploymentapi.GetParams{
+		API: client, DeploymentID: id,
+		QueryParams: deputil.QueryParams{
+			ShowPlans:       true,
+			ShowPlanLogs:    true,
+			ShowPlanHistory: true,
+		},
+	})
+	if err != nil {
+		return nil
+	}
+
+	return failedStepLogs(lastPlanLogs(res))
+}
+
+// lastPlanLogs returns the step logs of the most recent plan attempt of each
+// of the deployment resources.
+func lastPlanLogs(res *models.DeploymentGetResponse) []resourcePlanLog {
+	var logs []resourcePlanLog
+	if res == nil || res.Resources == nil {
+		return logs
+	}
+
+	for _, r := range res.Resources.Elasticsearch {
+		if r.Info == nil || r.Info.PlanInfo == nil || len(r.Info.PlanInfo.History) == 0 {
+			continue
+		}
+		last := r.Info.PlanInfo.History[len(r.Info.PlanInfo.History)-1]
+		logs = append(logs, resourcePlanLog{util.Elasticsearch, *r.RefID, last.PlanAttemptLog})
+	}
+	for _, r := range res.Resources.Kibana {
+		if r.Info == nil || r.Info.PlanInfo == nil || len(r.Info.PlanInfo.History) == 0 {
+			continue
+		}
+		last := r.Info.PlanInfo.History[len(r.Info.PlanInfo.History)-1]
+		logs = append(logs, resourcePlanLog{util.Kibana, *r.RefID, last.PlanAttemptLog})
+	}
+	for _, r := range res.Resources.Apm {
+		if r.Info == nil || r.Info.PlanInfo == nil || len(r.Info.PlanInfo.History) == 0 {
+			continue
+		}
+		last := r.Info.PlanInfo.History[len(r.Info.PlanInfo.History)-1]
+		logs = append(logs, resourcePlanLog{util.Apm, *r.RefID, last.PlanAttemptLog})
+	}
+	for _, r := range res.Resources.EnterpriseSearch {
+		if r.Info == nil || r.Info.PlanInfo == nil || len(r.Info.PlanInfo.History) == 0 {
+			continue
+		}
+		last := r.Info.PlanInfo.History[len(r.Info.PlanInfo.History)-1]
+		logs = append(logs, resourcePlanLog{util.EnterpriseSearch, *r.RefID, last.PlanAttemptLog})
+	}
+
+	return logs
+}
+
+// failedStepLogs returns an error for each of the last log messages of the
+// plan steps which have an "error" status.
+func failedStepLogs(logs []resourcePlanLog) []error {
+	var errs []error
+	for _, l := range logs {
+		for _, step := range l.steps {
+			if step == nil || step.Status == nil || *step.Status != models.ClusterPlanStepInfoStatusError {
+				continue
+			}
+
+			var messages = step.InfoLog
+			if len(messages) > maxPlanLogMessages {
+				messages = messages[len(messages)-maxPlanLogMessages:]
+			}
+
+			for _, m := range messages {
+				if m == nil || m.Message == nil {
+					continue
+				}
+				errs = append(errs, fmt.Errorf("%s %s: plan step %s: %s",
+					l.kind, l.refID, *step.StepID, truncate(*m.Message, maxPlanLogMessageLength),
+				))
+			}
+		}
+	}
+
+	return errs
+}
+
+func truncate(s string, length int) string {
+	if len(s) <= length {
+		return s
+	}
+	return s[:length] + "..."
+}
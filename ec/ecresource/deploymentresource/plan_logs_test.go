@@ -0,0 +1,90 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package deploymentresource
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/elastic/cloud-sdk-go/pkg/models"
+	"github.com/elastic/cloud-sdk-go/pkg/util/ec"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_failedStepLogs(t *testing.T) {
+	newLogs := func(msgs ...string) []*models.ClusterPlanStepLogMessageInfo {
+		var res []*models.ClusterPlanStepLogMessageInfo
+		for _, m := range msgs {
+			res = append(res, &models.ClusterPlanStepLogMessageInfo{Message: ec.String(m)})
+		}
+		return res
+	}
+	tests := []struct {
+		name string
+		logs []resourcePlanLog
+		want []error
+	}{
+		{
+			name: "no logs",
+		},
+		{
+			name: "ignores the successful steps",
+			logs: []resourcePlanLog{{kind: "elasticsearch", refID: "main-elasticsearch", steps: []*models.ClusterPlanStepInfo{
+				{StepID: ec.String("plan-completed"), Status: ec.String("success"), InfoLog: newLogs("done")},
+			}}},
+		},
+		{
+			name: "returns the failed step messages",
+			logs: []resourcePlanLog{
+				{kind: "elasticsearch", refID: "main-elasticsearch", steps: []*models.ClusterPlanStepInfo{
+					{StepID: ec.String("validate-plan"), Status: ec.String("success"), InfoLog: newLogs("valid")},
+					{StepID: ec.String("allocate-instances"), Status: ec.String("error"), InfoLog: newLogs(
+						"not enough capacity in zone eu-west-1a",
+					)},
+				}},
+				{kind: "kibana", refID: "main-kibana", steps: []*models.ClusterPlanStepInfo{
+					{StepID: ec.String("wait-until-running"), Status: ec.String("error"), InfoLog: newLogs(
+						"1", "2", "3", "4", "5", "6", strings.Repeat("a", 301),
+					)},
+				}},
+			},
+			want: []error{
+				errors.New("elasticsearch main-elasticsearch: plan step allocate-instances: not enough capacity in zone eu-west-1a"),
+				errors.New("kibana main-kibana: plan step wait-until-running: 3"),
+				errors.New("kibana main-kibana: plan step wait-until-running: 4"),
+				errors.New("kibana main-kibana: plan step wait-until-running: 5"),
+				errors.New("kibana main-kibana: plan step wait-until-running: 6"),
+				errors.New("kibana main-kibana: plan step wait-until-running: " + strings.Repeat("a", 300) + "..."),
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := failedStepLogs(tt.logs)
+			var gotMsgs, wantMsgs []string
+			for _, err := range got {
+				gotMsgs = append(gotMsgs, err.Error())
+			}
+			for _, err := range tt.want {
+				wantMsgs = append(wantMsgs, err.Error())
+			}
+			assert.Equal(t, wantMsgs, gotMsgs)
+		})
+	}
+}
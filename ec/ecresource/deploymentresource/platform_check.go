@@ -0,0 +1,80 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package deploymentresource
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/blang/semver/v4"
+	"github.com/elastic/cloud-sdk-go/pkg/multierror"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+
+	"github.com/elastic/terraform-provider-ec/ec/util"
+)
+
+// platformFeature is a deployment resource block which is only supported by
+// the ECE installations from a version onwards.
+type platformFeature struct {
+	attr    string
+	version semver.Version
+}
+
+var platformFeatures = []platformFeature{
+	{attr: "enterprise_search", version: semver.MustParse("2.6.0")},
+}
+
+// checkPlatformVersion verifies that the configured resource blocks are
+// supported by the targeted ECE installation, so the plan fails with a clear
+// error rather than the API rejecting the request with a validation error.
+func checkPlatformVersion(_ context.Context, d *schema.ResourceDiff, meta interface{}) error {
+	version := meta.(*util.ProviderMeta).PlatformVersion
+	if version == "" {
+		return nil
+	}
+
+	var configured []string
+	for _, f := range platformFeatures {
+		if d.Get(f.attr+".#").(int) > 0 {
+			configured = append(configured, f.attr)
+		}
+	}
+
+	return unsupportedPlatformFeatures(version, configured)
+}
+
+func unsupportedPlatformFeatures(version string, configured []string) error {
+	v, err := semver.ParseTolerant(version)
+	if err != nil {
+		return nil
+	}
+
+	var merr = multierror.NewPrefixed("unsupported ECE platform version")
+	for _, f := range platformFeatures {
+		for _, attr := range configured {
+			if attr == f.attr && v.LT(f.version) {
+				merr = merr.Append(fmt.Errorf(
+					"%s requires ECE %s or later, the installed version is %s",
+					f.attr, f.version, version,
+				))
+			}
+		}
+	}
+
+	return merr.ErrorOrNil()
+}
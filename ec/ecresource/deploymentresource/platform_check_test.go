@@ -0,0 +1,68 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package deploymentresource
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/elastic/cloud-sdk-go/pkg/multierror"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_unsupportedPlatformFeatures(t *testing.T) {
+	type args struct {
+		version    string
+		configured []string
+	}
+	tests := []struct {
+		name string
+		args args
+		err  error
+	}{
+		{
+			name: "returns no error without configured features",
+			args: args{version: "2.5.1"},
+		},
+		{
+			name: "returns no error when the version supports the features",
+			args: args{version: "2.6.0", configured: []string{"enterprise_search"}},
+		},
+		{
+			name: "returns no error when the version can't be parsed",
+			args: args{version: "unknown", configured: []string{"enterprise_search"}},
+		},
+		{
+			name: "returns an error when the version doesn't support a feature",
+			args: args{version: "2.5.1", configured: []string{"enterprise_search"}},
+			err: multierror.NewPrefixed("unsupported ECE platform version",
+				errors.New("enterprise_search requires ECE 2.6.0 or later, the installed version is 2.5.1"),
+			),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := unsupportedPlatformFeatures(tt.args.version, tt.args.configured)
+			if tt.err != nil {
+				assert.EqualError(t, err, tt.err.Error())
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
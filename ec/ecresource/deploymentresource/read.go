@@ -19,34 +19,67 @@ package deploymentresource
 
 import (
 	"context"
+	"errors"
+	"log"
 
-	"github.com/elastic/cloud-sdk-go/pkg/api"
-	"github.com/elastic/cloud-sdk-go/pkg/api/deploymentapi"
 	"github.com/elastic/cloud-sdk-go/pkg/api/deploymentapi/deputil"
 	"github.com/elastic/cloud-sdk-go/pkg/multierror"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+
+	"github.com/elastic/terraform-provider-ec/ec/util"
 )
 
 // Read queries the remote deployment state and updates the local state.
 func read(_ context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	client := meta.(*api.API)
-
-	res, err := deploymentapi.Get(deploymentapi.GetParams{
-		API: client, DeploymentID: d.Id(),
-		QueryParams: deputil.QueryParams{
-			ShowSettings:     true,
-			ShowPlans:        true,
-			ShowMetadata:     true,
-			ShowPlanDefaults: true,
-		},
-	})
+	providerMeta := meta.(*util.ProviderMeta)
+	client := providerMeta.API
+
+	var params = deputil.QueryParams{
+		ShowSettings:     true,
+		ShowPlans:        true,
+		ShowMetadata:     true,
+		ShowPlanDefaults: true,
+	}
 
+	res, err := util.GetDeployment(client, d.Id(), params)
 	if err != nil {
+		// The deployment was deleted outside of terraform, it's removed from
+		// the state so it's created again on the next apply.
+		if errors.Is(err, util.ErrDeploymentNotFound) {
+			log.Printf("[WARN] deployment %s not found, removing it from the state", d.Id())
+			d.SetId("")
+			return nil
+		}
 		return diag.FromErr(multierror.NewPrefixed("failed reading deployment", err))
 	}
 
-	if err := modelToState(d, res); err != nil {
+	// The deployment is still being created, e.g. its create timed out. Its
+	// configured values are kept rather than blocking the refresh until the
+	// plan finishes, the next update waits for the plan instead.
+	if isInitialPlanPending(res) {
+		log.Printf("[INFO] deployment %s is still being created, keeping its configured values", d.Id())
+		return nil
+	}
+
+	// The keystore contents and remote clusters are read before being
+	// overwritten by the flattened deployment, since they're managed through
+	// their own APIs rather than the deployment plan.
+	priorKeystore := toStringMap(d.Get(keystoreContentsKey))
+	managedRemoteClusters := d.Get(remoteClustersKey+".#").(int) > 0
+	if err := FlattenDeployment(d, res); err != nil {
+		return diag.FromErr(err)
+	}
+
+	if err := readKeystoreContents(d, client, priorKeystore); err != nil {
+		return diag.FromErr(err)
+	}
+
+	if err := readRemoteClusters(d, client, managedRemoteClusters); err != nil {
+		return diag.FromErr(err)
+	}
+
+	if err := d.Set("console_url", providerMeta.DeploymentConsoleURL(d.Id())); err != nil {
 		return diag.FromErr(err)
 	}
 
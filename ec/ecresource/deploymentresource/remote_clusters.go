@@ -0,0 +1,88 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package deploymentresource
+
+import (
+	"github.com/elastic/cloud-sdk-go/pkg/api"
+	"github.com/elastic/cloud-sdk-go/pkg/client/deployments"
+	"github.com/elastic/cloud-sdk-go/pkg/multierror"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+
+	"github.com/elastic/terraform-provider-ec/ec/ecresource/deploymentresource/elasticsearchstate"
+)
+
+const remoteClustersKey = "elasticsearch.0.remote_cluster"
+
+// handleRemoteClustersChange replaces the Elasticsearch resource's remote
+// clusters with the elasticsearch remote_cluster elements when they change.
+func handleRemoteClustersChange(d *schema.ResourceData, client *api.API) error {
+	if !d.HasChange(remoteClustersKey) {
+		return nil
+	}
+
+	var raw []interface{}
+	if set, ok := d.Get(remoteClustersKey).(*schema.Set); ok {
+		raw = set.List()
+	}
+
+	if _, err := client.V1API.Deployments.SetDeploymentEsResourceRemoteClusters(
+		deployments.NewSetDeploymentEsResourceRemoteClustersParams().
+			WithDeploymentID(d.Id()).
+			WithRefID(d.Get("elasticsearch.0.ref_id").(string)).
+			WithBody(elasticsearchstate.ExpandRemoteClusters(raw)),
+		client.AuthWriter,
+	); err != nil {
+		return multierror.NewPrefixed("failed updating the elasticsearch remote clusters",
+			api.UnwrapError(err),
+		)
+	}
+
+	return nil
+}
+
+// readRemoteClusters refreshes the elasticsearch remote_cluster elements. The
+// remote clusters are only read when they're managed through the resource, so
+// they don't conflict with the ec_deployment_remote_clusters resource, and the
+// deployments which don't use them don't cost an API call on every refresh.
+func readRemoteClusters(d *schema.ResourceData, client *api.API, managed bool) error {
+	es, _ := d.Get("elasticsearch").([]interface{})
+	if !managed || len(es) == 0 {
+		return nil
+	}
+
+	m, ok := es[0].(map[string]interface{})
+	if !ok {
+		return nil
+	}
+
+	refID, _ := m["ref_id"].(string)
+	res, err := client.V1API.Deployments.GetDeploymentEsResourceRemoteClusters(
+		deployments.NewGetDeploymentEsResourceRemoteClustersParams().
+			WithDeploymentID(d.Id()).
+			WithRefID(refID),
+		client.AuthWriter,
+	)
+	if err != nil {
+		return multierror.NewPrefixed("failed reading the elasticsearch remote clusters",
+			api.UnwrapError(err),
+		)
+	}
+
+	m["remote_cluster"] = elasticsearchstate.FlattenRemoteClusters(res.Payload)
+	return d.Set("elasticsearch", es)
+}
@@ -20,6 +20,7 @@ package deploymentresource
 import (
 	"time"
 
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/customdiff"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 )
 
@@ -31,6 +32,14 @@ func Resource() *schema.Resource {
 		UpdateContext: update,
 		DeleteContext: delete,
 
+		CustomizeDiff: customdiff.All(
+			checkTemplateResources,
+			checkPlatformVersion,
+			checkExtensions,
+			checkZoneCount,
+			checkSnapshotSource,
+		),
+
 		Schema: NewSchema(),
 
 		Description: "Elastic Cloud Deployment resource",
@@ -38,7 +47,7 @@ func Resource() *schema.Resource {
 			// It might be desired to provide the ability to import a deployment
 			// specifying key:value pairs of secrets to populate as part of the
 			// import with an implementation of schema.StateContextFunc.
-			StateContext: schema.ImportStatePassthroughContext,
+			StateContext: importFunc,
 		},
 
 		Timeouts: &schema.ResourceTimeout{
@@ -0,0 +1,121 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package deploymentresource
+
+import (
+	"context"
+	"errors"
+	"log"
+	"net"
+	"time"
+
+	"github.com/elastic/cloud-sdk-go/pkg/api/apierror"
+
+	"github.com/elastic/terraform-provider-ec/ec/ectransport"
+	"github.com/elastic/terraform-provider-ec/ec/util"
+)
+
+// transientRetryDelay is the time to wait between retries of a call which
+// failed with a transient error.
+var transientRetryDelay = 2 * time.Second
+
+// withTransientRetry calls fn until it succeeds, it returns a non transient
+// error, defaultMaxRetry is reached or the context is done. Since a timed out
+// request might have been processed by the API, it must only be used with
+// idempotent calls or with calls which reuse the same request ID on every
+// attempt.
+//
+// When the API is temporarily unavailable (i.e. during a maintenance window),
+// the call is retried after the time it asks for, for as long as the context
+// isn't done. These waits don't count towards defaultMaxRetry.
+func withTransientRetry(ctx context.Context, fn func() error) error {
+	for attempt := 0; ; {
+		err := fn()
+		if err == nil {
+			return nil
+		}
+
+		if wait, ok := ectransport.RetryAfter(err); ok {
+			if wait < transientRetryDelay {
+				wait = transientRetryDelay
+			}
+			log.Printf("[WARN] the Elastic Cloud API is temporarily unavailable, most likely due to maintenance, retrying in %s", wait)
+			if sleepContext(ctx, wait) != nil {
+				return err
+			}
+			continue
+		}
+
+		if !isTransientError(err) || attempt >= defaultMaxRetry {
+			return err
+		}
+
+		attempt++
+		if sleepContext(ctx, transientRetryDelay) != nil {
+			return err
+		}
+	}
+}
+
+// capacityRetryDelay is the time to wait before retrying a call which failed
+// because of insufficient capacity.
+var capacityRetryDelay = time.Minute
+
+// withCapacityRetry calls fn until it succeeds, it returns an error which isn't
+// caused by insufficient capacity, or the context is done. Since capacity may
+// take a long time to be freed up, it's only retried when the provider's
+// "retry_insufficient_capacity" feature is set.
+func withCapacityRetry(ctx context.Context, enabled bool, fn func() error) error {
+	for {
+		err := fn()
+		limitErr, ok := util.AsLimitError(err)
+		if !enabled || !ok || !limitErr.Transient() {
+			return err
+		}
+
+		log.Printf("[WARN] not enough capacity to apply the deployment changes, retrying in %s", capacityRetryDelay)
+		if sleepContext(ctx, capacityRetryDelay) != nil {
+			return err
+		}
+	}
+}
+
+// sleepContext waits for the specified duration or until the context is done,
+// in which case the context error is returned.
+func sleepContext(ctx context.Context, d time.Duration) error {
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
+
+// isTransientError returns true when the error is a network level timeout,
+// meaning that the request may or may not have reached the API.
+func isTransientError(err error) bool {
+	if errors.Is(err, apierror.ErrTimedOut) {
+		return true
+	}
+
+	var netErr net.Error
+	return errors.As(err, &netErr) && netErr.Timeout()
+}
@@ -0,0 +1,180 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package deploymentresource
+
+import (
+	"context"
+	"errors"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/elastic/cloud-sdk-go/pkg/api/apierror"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/elastic/terraform-provider-ec/ec/ectransport"
+)
+
+type timeoutError struct{}
+
+func (timeoutError) Error() string   { return "i/o timeout" }
+func (timeoutError) Timeout() bool   { return true }
+func (timeoutError) Temporary() bool { return true }
+
+func Test_withTransientRetry(t *testing.T) {
+	transientRetryDelay = 0
+	var urlErr = &url.Error{Op: "Post", URL: "https://api", Err: timeoutError{}}
+	var maintenanceErr = &url.Error{Op: "Post", URL: "https://api", Err: &ectransport.RetryAfterError{}}
+	var longMaintenanceErr = &url.Error{Op: "Post", URL: "https://api", Err: &ectransport.RetryAfterError{
+		Wait: time.Hour,
+	}}
+	var cancelled, cancel = context.WithCancel(context.Background())
+	cancel()
+	tests := []struct {
+		name      string
+		ctx       context.Context
+		errs      []error
+		wantCalls int
+		err       error
+	}{
+		{
+			name:      "succeeds on the first call",
+			errs:      []error{nil},
+			wantCalls: 1,
+		},
+		{
+			name:      "doesn't retry non transient errors",
+			errs:      []error{errors.New("some error")},
+			wantCalls: 1,
+			err:       errors.New("some error"),
+		},
+		{
+			name:      "retries transient errors until it succeeds",
+			errs:      []error{apierror.ErrTimedOut, urlErr, nil},
+			wantCalls: 3,
+		},
+		{
+			name: "returns the last error when the retries are exhausted",
+			errs: []error{
+				urlErr, urlErr, urlErr, urlErr, urlErr, urlErr,
+			},
+			wantCalls: defaultMaxRetry + 1,
+			err:       urlErr,
+		},
+		{
+			name: "retries while the API is unavailable without counting the retries",
+			errs: []error{
+				urlErr, urlErr, urlErr, urlErr, urlErr,
+				maintenanceErr, maintenanceErr, nil,
+			},
+			wantCalls: 8,
+		},
+		{
+			name:      "stops retrying when the context is done while the API is unavailable",
+			ctx:       cancelled,
+			errs:      []error{longMaintenanceErr},
+			wantCalls: 1,
+			err:       longMaintenanceErr,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var calls int
+			var ctx = tt.ctx
+			if ctx == nil {
+				ctx = context.Background()
+			}
+			err := withTransientRetry(ctx, func() error {
+				err := tt.errs[calls]
+				calls++
+				return err
+			})
+			if tt.err != nil {
+				assert.EqualError(t, err, tt.err.Error())
+			} else {
+				assert.NoError(t, err)
+			}
+			assert.Equal(t, tt.wantCalls, calls)
+		})
+	}
+}
+
+func Test_withTransientRetry_cancelled(t *testing.T) {
+	defer func(delay time.Duration) { transientRetryDelay = delay }(transientRetryDelay)
+	transientRetryDelay = time.Hour
+
+	var urlErr = &url.Error{Op: "Post", URL: "https://api", Err: timeoutError{}}
+	var ctx, cancel = context.WithCancel(context.Background())
+	cancel()
+
+	var calls int
+	err := withTransientRetry(ctx, func() error {
+		calls++
+		return urlErr
+	})
+	assert.EqualError(t, err, urlErr.Error())
+	assert.Equal(t, 1, calls)
+}
+
+func Test_withCapacityRetry(t *testing.T) {
+	capacityRetryDelay = 0
+	var capacityErr = errors.New("api error: clusters.cluster_plan_state_error: not enough capacity")
+	tests := []struct {
+		name      string
+		enabled   bool
+		errs      []error
+		wantCalls int
+		err       error
+	}{
+		{
+			name:      "doesn't retry when disabled",
+			errs:      []error{capacityErr},
+			wantCalls: 1,
+			err:       capacityErr,
+		},
+		{
+			name:      "doesn't retry errors which aren't caused by capacity",
+			enabled:   true,
+			errs:      []error{errors.New("some error")},
+			wantCalls: 1,
+			err:       errors.New("some error"),
+		},
+		{
+			name:      "retries capacity errors until it succeeds",
+			enabled:   true,
+			errs:      []error{capacityErr, capacityErr, nil},
+			wantCalls: 3,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var calls int
+			err := withCapacityRetry(context.Background(), tt.enabled, func() error {
+				err := tt.errs[calls]
+				calls++
+				return err
+			})
+			if tt.err != nil {
+				assert.EqualError(t, err, tt.err.Error())
+			} else {
+				assert.NoError(t, err)
+			}
+			assert.Equal(t, tt.wantCalls, calls)
+		})
+	}
+}
@@ -19,25 +19,31 @@ package deploymentresource
 
 import (
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+
+	"github.com/elastic/terraform-provider-ec/ec/util"
 )
 
 // NewSchema returns the schema for an "ec_deployment" resource.
 func NewSchema() map[string]*schema.Schema {
 	return map[string]*schema.Schema{
 		"version": {
-			Type:        schema.TypeString,
-			Description: "Required Elastic Stack version to use for all of the deployment resources",
-			Required:    true,
+			Type:             schema.TypeString,
+			Description:      "Required Elastic Stack version to use for all of the deployment resources",
+			Required:         true,
+			ValidateFunc:     util.ValidateVersion,
+			DiffSuppressFunc: suppressPatchDowngrade,
 		},
 		"region": {
-			Type:        schema.TypeString,
-			Description: `Required ESS region where to create the deployment, for ECE environments "ece-region" must be set`,
-			Required:    true,
+			Type:         schema.TypeString,
+			Description:  `Required ESS region where to create the deployment, for ECE environments "ece-region" must be set`,
+			Required:     true,
+			ValidateFunc: util.ValidateRegion,
 		},
 		"deployment_template_id": {
-			Type:        schema.TypeString,
-			Description: "Required Deployment Template identifier to create the deployment from",
-			Required:    true,
+			Type:         schema.TypeString,
+			Description:  "Required Deployment Template identifier to create the deployment from",
+			Required:     true,
+			ValidateFunc: util.ValidateDeploymentTemplateID,
 		},
 		"name": {
 			Type:        schema.TypeString,
@@ -49,6 +55,24 @@ func NewSchema() map[string]*schema.Schema {
 			Description: "Optional request_id to set on the create operation, only use when previous create attempts return with an error and a request_id is returned as part of the error",
 			Optional:    true,
 		},
+		"adopt_version_upgrades": {
+			Type:        schema.TypeBool,
+			Description: "Optional flag to adopt patch version upgrades applied outside of Terraform into the state, rather than planning a downgrade",
+			Optional:    true,
+			Default:     true,
+		},
+		"wait_for_healthy": {
+			Type:        schema.TypeBool,
+			Description: "Optional flag to wait for the deployment to report itself as healthy after it's created or updated, so the endpoints and credentials aren't available to other resources until it can serve requests",
+			Optional:    true,
+			Default:     false,
+		},
+		"wait_for_completion": {
+			Type:        schema.TypeBool,
+			Description: "Optional flag to wait for the deployment changes to be applied before returning, when false the operation returns as soon as the plan is accepted",
+			Optional:    true,
+			Default:     true,
+		},
 
 		// Computed ES Creds
 		"elasticsearch_username": {
@@ -70,6 +94,12 @@ func NewSchema() map[string]*schema.Schema {
 			Sensitive: true,
 		},
 
+		"console_url": {
+			Type:        schema.TypeString,
+			Description: "Computed URL of the deployment page in the Cloud console",
+			Computed:    true,
+		},
+
 		// Resources
 		"elasticsearch": {
 			Type:        schema.TypeList,
@@ -117,9 +147,9 @@ func NewSchema() map[string]*schema.Schema {
 }
 
 // suppressMissingOptionalConfigurationBlock handles configuration block attributes in the following scenario:
-//  * The resource schema includes an optional configuration block with defaults
-//  * The API response includes those defaults to refresh into the Terraform state
-//  * The operator's configuration omits the optional configuration block
+//   - The resource schema includes an optional configuration block with defaults
+//   - The API response includes those defaults to refresh into the Terraform state
+//   - The operator's configuration omits the optional configuration block
 func suppressMissingOptionalConfigurationBlock(k, old, new string, d *schema.ResourceData) bool {
 	return old == "1" && new == "0"
 }
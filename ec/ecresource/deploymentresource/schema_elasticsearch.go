@@ -18,18 +18,33 @@
 package deploymentresource
 
 import (
+	"regexp"
+
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+
+	"github.com/elastic/terraform-provider-ec/ec/util"
 )
 
+// sizeResources are the resources which a topology element can be sized by.
+var sizeResources = []string{"memory", "storage"}
+
+// remoteClusterAliasRegex matches the valid remote cluster aliases.
+var remoteClusterAliasRegex = regexp.MustCompile(`^[a-zA-Z0-9_-]+$`)
+
+// snapshotDurationRegex matches the snapshot settings durations, e.g. "30min".
+var snapshotDurationRegex = regexp.MustCompile(`^\d+ ?(d|h|min)$`)
+
 // NewSchema returns the schema for an "ec_deployment" resource.
 func newElasticsearchResource() *schema.Resource {
 	return &schema.Resource{
 		Schema: map[string]*schema.Schema{
 			"ref_id": {
-				Type:        schema.TypeString,
-				Description: "Optional ref_id to set on the Elasticsearch resource",
-				Default:     "main-elasticsearch",
-				Optional:    true,
+				Type:         schema.TypeString,
+				Description:  "Optional ref_id to set on the Elasticsearch resource",
+				Default:      "main-elasticsearch",
+				Optional:     true,
+				ValidateFunc: util.ValidateRefID,
 			},
 
 			// Computed attributes
@@ -69,6 +84,26 @@ func newElasticsearchResource() *schema.Resource {
 
 			"config": elasticsearchConfig(),
 
+			"extension": elasticsearchExtensionSchema(),
+
+			"keystore_contents": {
+				Type:        schema.TypeMap,
+				Description: `Optional Elasticsearch keystore settings, the values are never read back from the keystore`,
+				Optional:    true,
+				Sensitive:   true,
+				Elem:        &schema.Schema{Type: schema.TypeString},
+			},
+
+			"snapshot_source": elasticsearchSnapshotSourceSchema(),
+
+			"snapshot_settings": elasticsearchSnapshotSettingsSchema(),
+
+			"remote_cluster": elasticsearchRemoteClusterSchema(),
+
+			"trust_account": elasticsearchTrustAccountSchema(),
+
+			"trust_external": elasticsearchTrustExternalSchema(),
+
 			// This doesn't work properly. Deleting a monitoring setting doesn't work.
 			"monitoring_settings": elasticsearchMonitoringSchema(),
 		},
@@ -85,14 +120,22 @@ func elasticsearchTopologySchema() *schema.Schema {
 			Schema: map[string]*schema.Schema{
 				"instance_configuration_id": {
 					Type:        schema.TypeString,
-					Description: `Required Instance Configuration ID from the deployment template`,
-					Required:    true,
+					Description: `Optional Instance Configuration ID from the deployment template, defaults to the next template topology element`,
+					Optional:    true,
+					Computed:    true,
 				},
 				"memory_per_node": {
 					Type:        schema.TypeString,
-					Description: `Optional amount of memory per node in the "<size in GB>g" notation`,
-					Default:     "4g",
+					Description: `Optional amount of memory per node in the "<size in GB>g" notation, defaults to the deployment template size`,
 					Optional:    true,
+					Computed:    true,
+				},
+				"size_resource": {
+					Type:         schema.TypeString,
+					Description:  `Optional resource which the memory_per_node size applies to, either "memory" or "storage"`,
+					Default:      "memory",
+					Optional:     true,
+					ValidateFunc: validation.StringInSlice(sizeResources, false),
 				},
 				"node_count_per_zone": {
 					Type:     schema.TypeInt,
@@ -100,9 +143,9 @@ func elasticsearchTopologySchema() *schema.Schema {
 				},
 				"zone_count": {
 					Type:        schema.TypeInt,
-					Description: `Optional number of zones that the Elasticsearch cluster will span. This is used to set HA`,
-					Default:     1,
+					Description: `Optional number of zones that the Elasticsearch cluster will span. This is used to set HA, defaults to the deployment template zone count`,
 					Optional:    true,
+					Computed:    true,
 				},
 
 				// Node types
@@ -153,6 +196,156 @@ func elasticsearchMonitoringSchema() *schema.Schema {
 	}
 }
 
+func elasticsearchSnapshotSourceSchema() *schema.Schema {
+	return &schema.Schema{
+		Type:        schema.TypeList,
+		Optional:    true,
+		MaxItems:    1,
+		Description: `Optional snapshot source settings. Restore data from a snapshot of another deployment when the deployment is created`,
+		Elem: &schema.Resource{
+			Schema: map[string]*schema.Schema{
+				"source_elasticsearch_cluster_id": {
+					Type:        schema.TypeString,
+					Description: `Required ID of the Elasticsearch cluster, not to be confused with the deployment ID, that will be used as the source of the snapshot`,
+					Required:    true,
+				},
+				"snapshot_name": {
+					Type:        schema.TypeString,
+					Description: `Optional name of the snapshot to restore. Use "__latest_success__" to get the most recent successful snapshot`,
+					Default:     "__latest_success__",
+					Optional:    true,
+				},
+			},
+		},
+	}
+}
+
+func elasticsearchSnapshotSettingsSchema() *schema.Schema {
+	return &schema.Schema{
+		Type:        schema.TypeList,
+		Optional:    true,
+		Computed:    true,
+		MaxItems:    1,
+		Description: `Optional cluster snapshot settings, the unset values keep the current deployment settings`,
+		Elem: &schema.Resource{
+			Schema: map[string]*schema.Schema{
+				"interval": {
+					Type:         schema.TypeString,
+					Description:  `Optional interval between snapshots, in the "<length><unit>" notation where unit is one of d, h or min`,
+					Optional:     true,
+					Computed:     true,
+					ValidateFunc: validation.StringMatch(snapshotDurationRegex, `must be in the "<length><unit>" notation where unit is one of d, h or min`),
+				},
+				"retention_max_age": {
+					Type:         schema.TypeString,
+					Description:  `Optional total retention period for all the snapshots, in the "<length><unit>" notation where unit is one of d, h or min`,
+					Optional:     true,
+					Computed:     true,
+					ValidateFunc: validation.StringMatch(snapshotDurationRegex, `must be in the "<length><unit>" notation where unit is one of d, h or min`),
+				},
+				"retention_snapshots": {
+					Type:         schema.TypeInt,
+					Description:  `Optional number of snapshots to retain`,
+					Optional:     true,
+					Computed:     true,
+					ValidateFunc: validation.IntAtLeast(1),
+				},
+			},
+		},
+	}
+}
+
+func elasticsearchRemoteClusterSchema() *schema.Schema {
+	return &schema.Schema{
+		Type:        schema.TypeSet,
+		Optional:    true,
+		Description: `Optional Elasticsearch remote clusters to configure for cross-cluster search, which replace the current remote clusters`,
+		Elem: &schema.Resource{
+			Schema: map[string]*schema.Schema{
+				"deployment_id": {
+					Type:        schema.TypeString,
+					Description: `Required ID of the remote deployment`,
+					Required:    true,
+				},
+				"alias": {
+					Type:         schema.TypeString,
+					Description:  `Required alias for the remote cluster, which must only contain letters, digits, dashes and underscores`,
+					Required:     true,
+					ValidateFunc: validation.StringMatch(remoteClusterAliasRegex, "must only contain letters, digits, dashes and underscores"),
+				},
+				"ref_id": {
+					Type:        schema.TypeString,
+					Description: `Optional ref_id of the remote deployment's Elasticsearch resource`,
+					Default:     "main-elasticsearch",
+					Optional:    true,
+				},
+				"skip_unavailable": {
+					Type:        schema.TypeBool,
+					Description: `Optionally skips the remote cluster during search when it's disconnected`,
+					Default:     false,
+					Optional:    true,
+				},
+			},
+		},
+	}
+}
+
+func elasticsearchTrustAccountSchema() *schema.Schema {
+	return &schema.Schema{
+		Type:        schema.TypeSet,
+		Optional:    true,
+		Description: `Optional Elasticsearch account trust settings`,
+		Elem: &schema.Resource{
+			Schema: map[string]*schema.Schema{
+				"account_id": {
+					Type:        schema.TypeString,
+					Description: `Required ID of the account`,
+					Required:    true,
+				},
+				"trust_all": {
+					Type:        schema.TypeBool,
+					Description: `Required flag to trust all the deployments in the account`,
+					Required:    true,
+				},
+				"trust_allowlist": {
+					Type:        schema.TypeSet,
+					Description: `Optional list of the Elasticsearch cluster IDs to trust, only used when trust_all is false`,
+					Optional:    true,
+					Elem:        &schema.Schema{Type: schema.TypeString},
+				},
+			},
+		},
+	}
+}
+
+func elasticsearchTrustExternalSchema() *schema.Schema {
+	return &schema.Schema{
+		Type:        schema.TypeSet,
+		Optional:    true,
+		Description: `Optional Elasticsearch external trust settings`,
+		Elem: &schema.Resource{
+			Schema: map[string]*schema.Schema{
+				"relationship_id": {
+					Type:        schema.TypeString,
+					Description: `Required ID of the external trust relationship`,
+					Required:    true,
+				},
+				"trust_all": {
+					Type:        schema.TypeBool,
+					Description: `Required flag to trust all the deployments in the external entity`,
+					Required:    true,
+				},
+				"trust_allowlist": {
+					Type:        schema.TypeSet,
+					Description: `Optional list of the Elasticsearch cluster IDs to trust, only used when trust_all is false`,
+					Optional:    true,
+					Elem:        &schema.Schema{Type: schema.TypeString},
+				},
+			},
+		},
+	}
+}
+
 func elasticsearchConfig() *schema.Schema {
 	return &schema.Schema{
 		Type:             schema.TypeList,
@@ -164,10 +357,8 @@ func elasticsearchConfig() *schema.Schema {
 			Schema: map[string]*schema.Schema{
 				// Settings
 
-				// Ignored settings are: [ user_bundles and user_plugins ].
-				// Adding support for them will allow users to specify
-				// "Extensions" as it is possible in the UI today.
-				// The implementation would differ between ECE and ESS.
+				// user_bundles and user_plugins are set through the
+				// "extension" block.
 
 				// plugins maps to the `enabled_built_in_plugins` API setting.
 				"plugins": {
@@ -187,21 +378,58 @@ func elasticsearchConfig() *schema.Schema {
 					Type:        schema.TypeString,
 					Description: `JSON-formatted user level "elasticsearch.yml" setting overrides`,
 					Optional:    true,
+					Sensitive:   true,
 				},
 				"user_settings_override_json": {
 					Type:        schema.TypeString,
 					Description: `JSON-formatted admin (ECE) level "elasticsearch.yml" setting overrides`,
 					Optional:    true,
+					Sensitive:   true,
 				},
 				"user_settings_yaml": {
 					Type:        schema.TypeString,
 					Description: `YAML-formatted user level "elasticsearch.yml" setting overrides`,
 					Optional:    true,
+					Sensitive:   true,
 				},
 				"user_settings_override_yaml": {
 					Type:        schema.TypeString,
 					Description: `YAML-formatted admin (ECE) level "elasticsearch.yml" setting overrides`,
 					Optional:    true,
+					Sensitive:   true,
+				},
+			},
+		},
+	}
+}
+
+func elasticsearchExtensionSchema() *schema.Schema {
+	return &schema.Schema{
+		Type:        schema.TypeSet,
+		Optional:    true,
+		Description: `Optional Elasticsearch extensions such as custom bundles or plugins`,
+		Elem: &schema.Resource{
+			Schema: map[string]*schema.Schema{
+				"name": {
+					Type:        schema.TypeString,
+					Description: "Extension name",
+					Required:    true,
+				},
+				"type": {
+					Type:         schema.TypeString,
+					Description:  `Extension type, only "bundle" or "plugin" are supported`,
+					Required:     true,
+					ValidateFunc: validation.StringInSlice([]string{"bundle", "plugin"}, false),
+				},
+				"version": {
+					Type:        schema.TypeString,
+					Description: "Elasticsearch compatibility version, which must match the deployment version",
+					Required:    true,
+				},
+				"url": {
+					Type:        schema.TypeString,
+					Description: "Bundle or plugin URL, the extension URL can be obtained from the `ec_deployment_extension.<name>.url` attribute or the API and cannot be a random HTTP address that is hosted elsewhere",
+					Required:    true,
 				},
 			},
 		},
@@ -17,21 +17,28 @@
 
 package deploymentresource
 
-import "github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+import (
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+
+	"github.com/elastic/terraform-provider-ec/ec/util"
+)
 
 // NewSchema returns the schema for an "ec_deployment" resource.
 func newKibanaResource() *schema.Resource {
 	return &schema.Resource{
 		Schema: map[string]*schema.Schema{
 			"elasticsearch_cluster_ref_id": {
-				Type:     schema.TypeString,
-				Default:  "main-elasticsearch",
-				Optional: true,
+				Type:         schema.TypeString,
+				Default:      "main-elasticsearch",
+				Optional:     true,
+				ValidateFunc: util.ValidateRefID,
 			},
 			"ref_id": {
-				Type:     schema.TypeString,
-				Default:  "main-kibana",
-				Optional: true,
+				Type:         schema.TypeString,
+				Default:      "main-kibana",
+				Optional:     true,
+				ValidateFunc: util.ValidateRefID,
 			},
 			"resource_id": {
 				Type:     schema.TypeString,
@@ -73,12 +80,19 @@ func kibanaTopologySchema() *schema.Schema {
 
 				"instance_configuration_id": {
 					Type:     schema.TypeString,
-					Required: true,
+					Optional: true,
+					Computed: true,
 				},
 				"memory_per_node": {
 					Type:     schema.TypeString,
-					Default:  "1g",
 					Optional: true,
+					Computed: true,
+				},
+				"size_resource": {
+					Type:         schema.TypeString,
+					Default:      "memory",
+					Optional:     true,
+					ValidateFunc: validation.StringInSlice(sizeResources, false),
 				},
 				"node_count_per_zone": {
 					Type:     schema.TypeInt,
@@ -86,8 +100,8 @@ func kibanaTopologySchema() *schema.Schema {
 				},
 				"zone_count": {
 					Type:     schema.TypeInt,
-					Default:  1,
 					Optional: true,
+					Computed: true,
 				},
 			},
 		},
@@ -107,21 +121,25 @@ func kibanaConfig() *schema.Schema {
 					Type:        schema.TypeString,
 					Description: `An arbitrary JSON object allowing (non-admin) cluster owners to set their parameters (only one of this and 'user_settings_yaml' is allowed), provided they are on the whitelist ('user_settings_whitelist') and not on the blacklist ('user_settings_blacklist'). (This field together with 'user_settings_override*' and 'system_settings' defines the total set of resource settings)`,
 					Optional:    true,
+					Sensitive:   true,
 				},
 				"user_settings_override_json": {
 					Type:        schema.TypeString,
 					Description: `An arbitrary JSON object allowing ECE admins owners to set clusters' parameters (only one of this and 'user_settings_override_yaml' is allowed), ie in addition to the documented 'system_settings'. (This field together with 'system_settings' and 'user_settings*' defines the total set of resource settings)`,
 					Optional:    true,
+					Sensitive:   true,
 				},
 				"user_settings_yaml": {
 					Type:        schema.TypeString,
 					Description: `An arbitrary YAML object allowing ECE admins owners to set clusters' parameters (only one of this and 'user_settings_override_json' is allowed), ie in addition to the documented 'system_settings'. (This field together with 'system_settings' and 'user_settings*' defines the total set of resource settings)`,
 					Optional:    true,
+					Sensitive:   true,
 				},
 				"user_settings_override_yaml": {
 					Type:        schema.TypeString,
 					Description: `An arbitrary YAML object allowing (non-admin) cluster owners to set their parameters (only one of this and 'user_settings_json' is allowed), provided they are on the whitelist ('user_settings_whitelist') and not on the blacklist ('user_settings_blacklist'). (These field together with 'user_settings_override*' and 'system_settings' defines the total set of resource settings)`,
 					Optional:    true,
+					Sensitive:   true,
 				},
 			},
 		},
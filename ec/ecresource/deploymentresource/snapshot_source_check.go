@@ -0,0 +1,53 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package deploymentresource
+
+import (
+	"context"
+	"errors"
+	"reflect"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+const snapshotSourceKey = "elasticsearch.0.snapshot_source"
+
+// checkSnapshotSource rejects snapshot_source changes on existing deployments,
+// since the snapshot is only restored when the deployment is created and the
+// change would otherwise be silently ignored. Removing it is allowed.
+func checkSnapshotSource(_ context.Context, d *schema.ResourceDiff, _ interface{}) error {
+	if d.Id() == "" || !d.HasChange(snapshotSourceKey) {
+		return nil
+	}
+
+	rawPrior, rawPlanned := d.GetChange(snapshotSourceKey)
+	prior, _ := rawPrior.([]interface{})
+	planned, _ := rawPlanned.([]interface{})
+	return snapshotSourceChange(prior, planned)
+}
+
+func snapshotSourceChange(prior, planned []interface{}) error {
+	if len(planned) == 0 || reflect.DeepEqual(prior, planned) {
+		return nil
+	}
+
+	return errors.New(
+		"elasticsearch snapshot_source: the snapshot is only restored when the deployment is created, " +
+			"it can't be changed on an existing deployment",
+	)
+}
@@ -0,0 +1,76 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package deploymentresource
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_snapshotSourceChange(t *testing.T) {
+	source := func(id string) []interface{} {
+		return []interface{}{map[string]interface{}{
+			"source_elasticsearch_cluster_id": id,
+			"snapshot_name":                   "__latest_success__",
+		}}
+	}
+	type args struct {
+		prior   []interface{}
+		planned []interface{}
+	}
+	tests := []struct {
+		name string
+		args args
+		err  error
+	}{
+		{
+			name: "unchanged snapshot source",
+			args: args{prior: source("some-cluster"), planned: source("some-cluster")},
+		},
+		{
+			name: "removed snapshot source",
+			args: args{prior: source("some-cluster")},
+		},
+		{
+			name: "changed snapshot source",
+			args: args{prior: source("some-cluster"), planned: source("other-cluster")},
+			err: errors.New("elasticsearch snapshot_source: the snapshot is only restored when the deployment is created, " +
+				"it can't be changed on an existing deployment",
+			),
+		},
+		{
+			name: "added snapshot source",
+			args: args{planned: source("some-cluster")},
+			err: errors.New("elasticsearch snapshot_source: the snapshot is only restored when the deployment is created, " +
+				"it can't be changed on an existing deployment",
+			),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := snapshotSourceChange(tt.args.prior, tt.args.planned)
+			if tt.err != nil {
+				assert.EqualError(t, err, tt.err.Error())
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
@@ -0,0 +1,186 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package deploymentresource
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/elastic/cloud-sdk-go/pkg/api/deploymentapi/deptemplateapi"
+	"github.com/elastic/cloud-sdk-go/pkg/models"
+	"github.com/elastic/cloud-sdk-go/pkg/multierror"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+
+	"github.com/elastic/terraform-provider-ec/ec/util"
+)
+
+// optionalResourceKinds are the resource blocks which a deployment template
+// may or may not offer.
+var optionalResourceKinds = []string{"kibana", "apm", "enterprise_search"}
+
+// topologyKinds are the resource blocks with a topology, in the order used by
+// topologiesByKind.
+var topologyKinds = [4]string{"elasticsearch", "kibana", "apm", "enterprise_search"}
+
+// checkTemplateResources verifies that the deployment template of a deployment
+// offers all the configured resource kinds and instance configurations, so an
+// unsupported kind or topology element fails the plan rather than the create
+// or update request. Existing deployments are only checked when a topology
+// changes, and the resource kinds only when the deployment is created.
+func checkTemplateResources(_ context.Context, d *schema.ResourceDiff, meta interface{}) error {
+	if !d.NewValueKnown("deployment_template_id") || !d.NewValueKnown("region") {
+		return nil
+	}
+
+	var kinds []string
+	if d.Id() == "" {
+		for _, kind := range optionalResourceKinds {
+			if d.Get(kind+".#").(int) > 0 {
+				kinds = append(kinds, kind)
+			}
+		}
+	}
+
+	var declared [4][]string
+	var hasDeclared bool
+	for i, kind := range topologyKinds {
+		if d.Id() != "" && !d.HasChange(kind) {
+			continue
+		}
+
+		if d.Get(kind+".#").(int) == 0 {
+			continue
+		}
+
+		for _, raw := range d.Get(kind + ".0.topology").([]interface{}) {
+			topology, ok := raw.(map[string]interface{})
+			if !ok {
+				continue
+			}
+
+			// Unknown and unset instance configurations are skipped, the
+			// latter are filled in from the deployment template.
+			if id, ok := topology["instance_configuration_id"].(string); ok && id != "" {
+				declared[i] = append(declared[i], id)
+				hasDeclared = true
+			}
+		}
+	}
+
+	if len(kinds) == 0 && !hasDeclared {
+		return nil
+	}
+
+	templateID := d.Get("deployment_template_id").(string)
+	res, err := deptemplateapi.Get(deptemplateapi.GetParams{
+		API:                        meta.(*util.ProviderMeta).API,
+		TemplateID:                 templateID,
+		Region:                     d.Get("region").(string),
+		HideInstanceConfigurations: true,
+	})
+	if err != nil {
+		return multierror.NewPrefixed("failed obtaining deployment template", err)
+	}
+
+	if err := unsupportedResourceKinds(templateID, res.DeploymentTemplate, kinds); err != nil {
+		return err
+	}
+
+	return unknownInstanceConfigurations(templateID, res.DeploymentTemplate, declared)
+}
+
+// unknownInstanceConfigurations returns an error naming the declared instance
+// configurations which aren't part of the deployment template topology of
+// their resource kind.
+func unknownInstanceConfigurations(templateID string, tpl *models.DeploymentCreateRequest, declared [4][]string) error {
+	var resources = new(models.DeploymentCreateResources)
+	if tpl != nil && tpl.Resources != nil {
+		resources = tpl.Resources
+	}
+
+	var merr = multierror.NewPrefixed(fmt.Sprintf(
+		`deployment template "%s" doesn't offer the configured topology`, templateID,
+	))
+	for i, elems := range topologiesByKind(resources) {
+		var known = make(map[string]bool, len(elems))
+		var ids = make([]string, 0, len(elems))
+		for _, e := range elems {
+			if !known[*e.id] {
+				known[*e.id] = true
+				ids = append(ids, *e.id)
+			}
+		}
+
+		for _, id := range declared[i] {
+			if known[id] {
+				continue
+			}
+
+			if match, ok := util.ClosestMatch(id, ids); ok {
+				merr = merr.Append(fmt.Errorf(
+					`%s: unknown instance_configuration_id "%s", did you mean "%s"?`,
+					topologyKinds[i], id, match,
+				))
+				continue
+			}
+
+			merr = merr.Append(fmt.Errorf(
+				`%s: unknown instance_configuration_id "%s", use one of [%s]`,
+				topologyKinds[i], id, strings.Join(ids, ", "),
+			))
+		}
+	}
+
+	return merr.ErrorOrNil()
+}
+
+// unsupportedResourceKinds returns an error naming the kinds which the
+// deployment template doesn't offer.
+func unsupportedResourceKinds(templateID string, tpl *models.DeploymentCreateRequest, kinds []string) error {
+	var resources = new(models.DeploymentCreateResources)
+	if tpl != nil && tpl.Resources != nil {
+		resources = tpl.Resources
+	}
+
+	var unsupported []string
+	for _, kind := range kinds {
+		var supported bool
+		switch kind {
+		case "kibana":
+			supported = len(resources.Kibana) > 0
+		case "apm":
+			supported = len(resources.Apm) > 0
+		case "enterprise_search":
+			supported = len(resources.EnterpriseSearch) > 0
+		}
+
+		if !supported {
+			unsupported = append(unsupported, kind)
+		}
+	}
+
+	if len(unsupported) == 0 {
+		return nil
+	}
+
+	return fmt.Errorf(
+		`deployment template "%s" doesn't support the %s resource kind(s): remove the block(s) or choose a different deployment_template_id`,
+		templateID, strings.Join(unsupported, ", "),
+	)
+}
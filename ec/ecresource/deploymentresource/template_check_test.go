@@ -0,0 +1,135 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package deploymentresource
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/elastic/cloud-sdk-go/pkg/models"
+	"github.com/elastic/cloud-sdk-go/pkg/multierror"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_unsupportedResourceKinds(t *testing.T) {
+	type args struct {
+		tpl   *models.DeploymentCreateRequest
+		kinds []string
+	}
+	tests := []struct {
+		name string
+		args args
+		err  error
+	}{
+		{
+			name: "all the kinds are supported",
+			args: args{
+				tpl: &models.DeploymentCreateRequest{Resources: &models.DeploymentCreateResources{
+					Kibana: []*models.KibanaPayload{{}},
+					Apm:    []*models.ApmPayload{{}},
+				}},
+				kinds: []string{"kibana", "apm"},
+			},
+		},
+		{
+			name: "returns the unsupported kinds",
+			args: args{
+				tpl: &models.DeploymentCreateRequest{Resources: &models.DeploymentCreateResources{
+					Kibana: []*models.KibanaPayload{{}},
+				}},
+				kinds: []string{"kibana", "apm", "enterprise_search"},
+			},
+			err: errors.New(`deployment template "aws-io-optimized" doesn't support the apm, enterprise_search resource kind(s): remove the block(s) or choose a different deployment_template_id`),
+		},
+		{
+			name: "template without resources",
+			args: args{kinds: []string{"kibana"}},
+			err:  errors.New(`deployment template "aws-io-optimized" doesn't support the kibana resource kind(s): remove the block(s) or choose a different deployment_template_id`),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := unsupportedResourceKinds("aws-io-optimized", tt.args.tpl, tt.args.kinds)
+			if tt.err != nil {
+				assert.EqualError(t, err, tt.err.Error())
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
+
+func Test_unknownInstanceConfigurations(t *testing.T) {
+	tpl := &models.DeploymentCreateRequest{Resources: &models.DeploymentCreateResources{
+		Elasticsearch: []*models.ElasticsearchPayload{{
+			Plan: &models.ElasticsearchClusterPlan{
+				ClusterTopology: []*models.ElasticsearchClusterTopologyElement{
+					{InstanceConfigurationID: "aws.data.highio.i3"},
+					{InstanceConfigurationID: "aws.ml.m5"},
+				},
+			},
+		}},
+		Kibana: []*models.KibanaPayload{{
+			Plan: &models.KibanaClusterPlan{
+				ClusterTopology: []*models.KibanaClusterTopologyElement{
+					{InstanceConfigurationID: "aws.kibana.r5d"},
+				},
+			},
+		}},
+	}}
+	type args struct {
+		tpl      *models.DeploymentCreateRequest
+		declared [4][]string
+	}
+	tests := []struct {
+		name string
+		args args
+		err  error
+	}{
+		{
+			name: "all the instance configurations are offered",
+			args: args{tpl: tpl, declared: [4][]string{
+				{"aws.data.highio.i3", "aws.ml.m5"},
+				{"aws.kibana.r5d"},
+			}},
+		},
+		{
+			name: "returns the unknown instance configurations",
+			args: args{tpl: tpl, declared: [4][]string{
+				{"aws.data.highio.i3x"},
+				{"aws.data.highio.i3"},
+				{"aws.apm.r5d"},
+			}},
+			err: multierror.NewPrefixed(`deployment template "aws-io-optimized" doesn't offer the configured topology`,
+				errors.New(`elasticsearch: unknown instance_configuration_id "aws.data.highio.i3x", did you mean "aws.data.highio.i3"?`),
+				errors.New(`kibana: unknown instance_configuration_id "aws.data.highio.i3", use one of [aws.kibana.r5d]`),
+				errors.New(`apm: unknown instance_configuration_id "aws.apm.r5d", use one of []`),
+			),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := unknownInstanceConfigurations("aws-io-optimized", tt.args.tpl, tt.args.declared)
+			if tt.err != nil {
+				assert.EqualError(t, err, tt.err.Error())
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
@@ -0,0 +1,178 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package deploymentresource
+
+import (
+	"github.com/elastic/cloud-sdk-go/pkg/api"
+	"github.com/elastic/cloud-sdk-go/pkg/api/deploymentapi/deptemplateapi"
+	"github.com/elastic/cloud-sdk-go/pkg/models"
+	"github.com/elastic/cloud-sdk-go/pkg/multierror"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+// topologyElement points to the fields of a topology element which default to
+// the deployment template values, regardless of its resource kind.
+type topologyElement struct {
+	id        *string
+	size      **models.TopologySize
+	zoneCount *int32
+}
+
+// ApplyTemplateDefaults fills the instance_configuration_id, memory_per_node
+// and zone_count of the topology elements which don't set them with the
+// deployment template defaults, so minimal configurations match what the UI
+// creates. The deployment template is only obtained when it's needed. It's
+// applied to the ExpandCreateRequest and ExpandUpdateRequest resources before
+// they're sent.
+func ApplyTemplateDefaults(client *api.API, d *schema.ResourceData, res *models.DeploymentCreateResources) error {
+	var kinds = topologiesByKind(res)
+	if !needsTemplateDefaults(kinds) {
+		return nil
+	}
+
+	tpl, err := deptemplateapi.Get(deptemplateapi.GetParams{
+		API:                        client,
+		TemplateID:                 d.Get("deployment_template_id").(string),
+		Region:                     d.Get("region").(string),
+		StackVersion:               d.Get("version").(string),
+		HideInstanceConfigurations: true,
+	})
+	if err != nil {
+		return multierror.NewPrefixed("failed obtaining the deployment template defaults", err)
+	}
+
+	var defaults = topologiesByKind(new(models.DeploymentCreateResources))
+	if tpl.DeploymentTemplate != nil && tpl.DeploymentTemplate.Resources != nil {
+		defaults = topologiesByKind(tpl.DeploymentTemplate.Resources)
+	}
+
+	for i := range kinds {
+		applyTopologyDefaults(kinds[i], defaults[i])
+	}
+
+	return nil
+}
+
+// topologiesByKind returns the topology elements of the Elasticsearch, Kibana,
+// APM and Enterprise Search resources, in that order.
+func topologiesByKind(res *models.DeploymentCreateResources) [4][]topologyElement {
+	var result [4][]topologyElement
+	for _, r := range res.Elasticsearch {
+		if r == nil || r.Plan == nil {
+			continue
+		}
+		for _, t := range r.Plan.ClusterTopology {
+			if t == nil {
+				continue
+			}
+			result[0] = append(result[0], topologyElement{&t.InstanceConfigurationID, &t.Size, &t.ZoneCount})
+		}
+	}
+
+	for _, r := range res.Kibana {
+		if r == nil || r.Plan == nil {
+			continue
+		}
+		for _, t := range r.Plan.ClusterTopology {
+			if t == nil {
+				continue
+			}
+			result[1] = append(result[1], topologyElement{&t.InstanceConfigurationID, &t.Size, &t.ZoneCount})
+		}
+	}
+
+	for _, r := range res.Apm {
+		if r == nil || r.Plan == nil {
+			continue
+		}
+		for _, t := range r.Plan.ClusterTopology {
+			if t == nil {
+				continue
+			}
+			result[2] = append(result[2], topologyElement{&t.InstanceConfigurationID, &t.Size, &t.ZoneCount})
+		}
+	}
+
+	for _, r := range res.EnterpriseSearch {
+		if r == nil || r.Plan == nil {
+			continue
+		}
+		for _, t := range r.Plan.ClusterTopology {
+			if t == nil {
+				continue
+			}
+			result[3] = append(result[3], topologyElement{&t.InstanceConfigurationID, &t.Size, &t.ZoneCount})
+		}
+	}
+
+	return result
+}
+
+func needsTemplateDefaults(kinds [4][]topologyElement) bool {
+	for _, elems := range kinds {
+		for _, e := range elems {
+			if *e.id == "" || !hasSize(*e.size) || *e.zoneCount == 0 {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// applyTopologyDefaults fills the unset fields of the topology elements with
+// the values of the template element with the same instance configuration.
+// Elements without an instance configuration take the next template element
+// which has a default size and isn't used by any other element.
+func applyTopologyDefaults(elems, defaults []topologyElement) {
+	var used = make(map[string]bool, len(elems))
+	for _, e := range elems {
+		used[*e.id] = true
+	}
+
+	for _, e := range elems {
+		if *e.id == "" {
+			for _, def := range defaults {
+				if !used[*def.id] && hasSize(*def.size) && *(*def.size).Value > 0 {
+					*e.id = *def.id
+					used[*def.id] = true
+					break
+				}
+			}
+		}
+
+		for _, def := range defaults {
+			if *def.id != *e.id {
+				continue
+			}
+
+			if !hasSize(*e.size) && hasSize(*def.size) {
+				size := **def.size
+				*e.size = &size
+			}
+
+			if *e.zoneCount == 0 {
+				*e.zoneCount = *def.zoneCount
+			}
+			break
+		}
+	}
+}
+
+func hasSize(size *models.TopologySize) bool {
+	return size != nil && size.Value != nil
+}
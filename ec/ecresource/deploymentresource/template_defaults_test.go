@@ -0,0 +1,193 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package deploymentresource
+
+import (
+	"testing"
+
+	"github.com/elastic/cloud-sdk-go/pkg/models"
+	"github.com/elastic/cloud-sdk-go/pkg/util/ec"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_applyTopologyDefaults(t *testing.T) {
+	memory := func(v int32) *models.TopologySize {
+		return &models.TopologySize{Resource: ec.String("memory"), Value: ec.Int32(v)}
+	}
+	newTemplate := func() *models.DeploymentCreateResources {
+		return &models.DeploymentCreateResources{
+			Elasticsearch: []*models.ElasticsearchPayload{{
+				Plan: &models.ElasticsearchClusterPlan{
+					ClusterTopology: []*models.ElasticsearchClusterTopologyElement{
+						{InstanceConfigurationID: "aws.data.highio.i3", Size: memory(8192), ZoneCount: 2},
+						{InstanceConfigurationID: "aws.ml.m5", Size: memory(0), ZoneCount: 1},
+						{InstanceConfigurationID: "aws.data.highstorage.d2", Size: memory(4096), ZoneCount: 2},
+					},
+				},
+			}},
+			Kibana: []*models.KibanaPayload{{
+				Plan: &models.KibanaClusterPlan{
+					ClusterTopology: []*models.KibanaClusterTopologyElement{
+						{InstanceConfigurationID: "aws.kibana.r5d", Size: memory(1024), ZoneCount: 1},
+					},
+				},
+			}},
+		}
+	}
+	type args struct {
+		res *models.DeploymentCreateResources
+		tpl *models.DeploymentCreateResources
+	}
+	tests := []struct {
+		name string
+		args args
+		want *models.DeploymentCreateResources
+	}{
+		{
+			name: "fills the unset fields from the template",
+			args: args{
+				tpl: newTemplate(),
+				res: &models.DeploymentCreateResources{
+					Elasticsearch: []*models.ElasticsearchPayload{{
+						Plan: &models.ElasticsearchClusterPlan{
+							ClusterTopology: []*models.ElasticsearchClusterTopologyElement{
+								{InstanceConfigurationID: "aws.data.highio.i3", Size: &models.TopologySize{}},
+								{Size: memory(2048)},
+								{InstanceConfigurationID: "aws.ml.m5", Size: memory(1024)},
+							},
+						},
+					}},
+					Kibana: []*models.KibanaPayload{{
+						Plan: &models.KibanaClusterPlan{
+							ClusterTopology: []*models.KibanaClusterTopologyElement{
+								{Size: &models.TopologySize{}, ZoneCount: 2},
+							},
+						},
+					}},
+				},
+			},
+			want: &models.DeploymentCreateResources{
+				Elasticsearch: []*models.ElasticsearchPayload{{
+					Plan: &models.ElasticsearchClusterPlan{
+						ClusterTopology: []*models.ElasticsearchClusterTopologyElement{
+							{InstanceConfigurationID: "aws.data.highio.i3", Size: memory(8192), ZoneCount: 2},
+							{InstanceConfigurationID: "aws.data.highstorage.d2", Size: memory(2048), ZoneCount: 2},
+							{InstanceConfigurationID: "aws.ml.m5", Size: memory(1024), ZoneCount: 1},
+						},
+					},
+				}},
+				Kibana: []*models.KibanaPayload{{
+					Plan: &models.KibanaClusterPlan{
+						ClusterTopology: []*models.KibanaClusterTopologyElement{
+							{InstanceConfigurationID: "aws.kibana.r5d", Size: memory(1024), ZoneCount: 2},
+						},
+					},
+				}},
+			},
+		},
+		{
+			name: "keeps the fields of unknown instance configurations",
+			args: args{
+				tpl: newTemplate(),
+				res: &models.DeploymentCreateResources{
+					Elasticsearch: []*models.ElasticsearchPayload{{
+						Plan: &models.ElasticsearchClusterPlan{
+							ClusterTopology: []*models.ElasticsearchClusterTopologyElement{
+								{InstanceConfigurationID: "custom.data", Size: &models.TopologySize{}},
+							},
+						},
+					}},
+				},
+			},
+			want: &models.DeploymentCreateResources{
+				Elasticsearch: []*models.ElasticsearchPayload{{
+					Plan: &models.ElasticsearchClusterPlan{
+						ClusterTopology: []*models.ElasticsearchClusterTopologyElement{
+							{InstanceConfigurationID: "custom.data", Size: &models.TopologySize{}},
+						},
+					},
+				}},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			kinds := topologiesByKind(tt.args.res)
+			defaults := topologiesByKind(tt.args.tpl)
+			for i := range kinds {
+				applyTopologyDefaults(kinds[i], defaults[i])
+			}
+			assert.Equal(t, tt.want, tt.args.res)
+		})
+	}
+}
+
+func Test_needsTemplateDefaults(t *testing.T) {
+	complete := &models.ElasticsearchClusterTopologyElement{
+		InstanceConfigurationID: "aws.data.highio.i3",
+		Size:                    &models.TopologySize{Resource: ec.String("memory"), Value: ec.Int32(4096)},
+		ZoneCount:               1,
+	}
+	tests := []struct {
+		name string
+		elem *models.ElasticsearchClusterTopologyElement
+		want bool
+	}{
+		{
+			name: "complete topology elements don't need the template",
+			elem: complete,
+			want: false,
+		},
+		{
+			name: "missing instance configuration needs the template",
+			elem: &models.ElasticsearchClusterTopologyElement{
+				Size: complete.Size, ZoneCount: 1,
+			},
+			want: true,
+		},
+		{
+			name: "missing size needs the template",
+			elem: &models.ElasticsearchClusterTopologyElement{
+				InstanceConfigurationID: "aws.data.highio.i3",
+				Size:                    &models.TopologySize{},
+				ZoneCount:               1,
+			},
+			want: true,
+		},
+		{
+			name: "missing zone count needs the template",
+			elem: &models.ElasticsearchClusterTopologyElement{
+				InstanceConfigurationID: "aws.data.highio.i3",
+				Size:                    complete.Size,
+			},
+			want: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := needsTemplateDefaults(topologiesByKind(&models.DeploymentCreateResources{
+				Elasticsearch: []*models.ElasticsearchPayload{{
+					Plan: &models.ElasticsearchClusterPlan{
+						ClusterTopology: []*models.ElasticsearchClusterTopologyElement{tt.elem},
+					},
+				}},
+			}))
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
@@ -19,64 +19,195 @@ package deploymentresource
 
 import (
 	"context"
+	"fmt"
+	"log"
 	"strings"
 
 	"github.com/elastic/cloud-sdk-go/pkg/api"
 	"github.com/elastic/cloud-sdk-go/pkg/api/deploymentapi"
+	"github.com/elastic/cloud-sdk-go/pkg/api/deploymentapi/deputil"
+	"github.com/elastic/cloud-sdk-go/pkg/models"
 	"github.com/elastic/cloud-sdk-go/pkg/multierror"
+	"github.com/elastic/cloud-sdk-go/pkg/util/ec"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+
+	"github.com/elastic/terraform-provider-ec/ec/ecresource/deploymentresource/elasticsearchstate"
+	"github.com/elastic/terraform-provider-ec/ec/util"
 )
 
 // Update syncs the remote state with the local.
 func update(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	client := meta.(*api.API)
+	providerMeta := meta.(*util.ProviderMeta)
+	client := providerMeta.API
+	defer util.LockDeployment(d.Id())()
+	defer forgetTrafficFilters(d, providerMeta)
+
+	deploymentChange := hasDeploymentChange(d)
+	if deploymentChange {
+		if err := waitForInitialPlan(ctx, client, d.Id()); err != nil {
+			return diag.FromErr(multierror.NewPrefixed("failed tracking create progress", err))
+		}
 
-	if hasDeploymentChange(d) {
-		if err := updateDeployment(ctx, d, client); err != nil {
-			return diag.FromErr(err)
+		if err := updateDeployment(ctx, d, providerMeta); err != nil {
+			// Refresh the state with the deployment's actual configuration
+			// when the update is interrupted and its plan cancelled.
+			if ctx.Err() != nil {
+				return append(diag.FromErr(err), read(ctx, d, meta)...)
+			}
+			return util.ErrorDiagnostics(err)
 		}
 	}
 
-	if err := handleTrafficFilterChange(d, client); err != nil {
+	if err := handleTrafficFilterChange(d, providerMeta); err != nil {
+		return diag.FromErr(err)
+	}
+
+	if err := handleKeystoreChange(d, client); err != nil {
+		return diag.FromErr(err)
+	}
+
+	if err := handleRemoteClustersChange(d, client); err != nil {
 		return diag.FromErr(err)
 	}
 
+	// The pending plan hasn't been applied yet, reading the deployment would
+	// overwrite the configured values with the current ones.
+	if deploymentChange && !d.Get("wait_for_completion").(bool) {
+		return nil
+	}
+
+	// The endpoints aren't refreshed until the deployment becomes healthy.
+	if deploymentChange {
+		if diags := awaitHealthy(ctx, d, providerMeta); diags.HasError() {
+			return diags
+		}
+	}
+
 	return read(ctx, d, meta)
 }
 
-func updateDeployment(_ context.Context, d *schema.ResourceData, client *api.API) error {
-	req, err := updateResourceToModel(d)
+func updateDeployment(ctx context.Context, d *schema.ResourceData, providerMeta *util.ProviderMeta) error {
+	client := providerMeta.API
+	req, err := ExpandUpdateRequest(d)
 	if err != nil {
 		return err
 	}
 
-	res, err := deploymentapi.Update(deploymentapi.UpdateParams{
-		API:          client,
-		DeploymentID: d.Id(),
-		Request:      req,
-		Overrides: deploymentapi.PayloadOverrides{
-			Version: d.Get("version").(string),
-			Region:  d.Get("region").(string),
-		},
+	if err := resetRemovedTrust(client, d, req.Resources.Elasticsearch); err != nil {
+		return err
+	}
+
+	// Topology elements which were added without some of their values take
+	// the deployment template defaults, the existing ones keep their state.
+	if err := ApplyTemplateDefaults(client, d, &models.DeploymentCreateResources{
+		Apm:              req.Resources.Apm,
+		Elasticsearch:    req.Resources.Elasticsearch,
+		EnterpriseSearch: req.Resources.EnterpriseSearch,
+		Kibana:           req.Resources.Kibana,
+	}); err != nil {
+		return err
+	}
+
+	if providerMeta.Features.Deployment.PruneOrphans {
+		req.PruneOrphans = ec.Bool(true)
+	}
+
+	dumpPayload("update", d.Id(), req)
+
+	// Updates are idempotent since the full deployment payload is sent.
+	var res *models.DeploymentUpdateResponse
+	err = withCapacityRetry(ctx, providerMeta.Features.Deployment.RetryInsufficientCapacity, func() error {
+		return withTransientRetry(ctx, func() (err error) {
+			res, err = deploymentapi.Update(deploymentapi.UpdateParams{
+				API:          client,
+				DeploymentID: d.Id(),
+				Request:      req,
+				Overrides: deploymentapi.PayloadOverrides{
+					Version: d.Get("version").(string),
+					Region:  d.Get("region").(string),
+				},
+			})
+			return err
+		})
 	})
 
 	if err != nil {
 		return multierror.NewPrefixed("failed updating deployment", err)
 	}
 
-	if err := WaitForPlanCompletion(client, d.Id()); err != nil {
-		return multierror.NewPrefixed("failed tracking update progress", err)
+	if !d.Get("wait_for_completion").(bool) {
+		return parseCredentials(d, res.Resources)
+	}
+
+	if err := waitForPlanCompletionContext(ctx, client, d.Id()); err != nil {
+		merr := multierror.NewPrefixed("failed tracking update progress", err)
+		if ctx.Err() == nil {
+			merr = merr.Append(planFailureLogs(client, d.Id())...)
+			merr = merr.Append(newConsoleURLError(providerMeta, d.Id()))
+		}
+		return merr
 	}
 
 	return parseCredentials(d, res.Resources)
 }
 
+// resetRemovedTrust restores the default trust settings of the Elasticsearch
+// payloads whose last trust_account or trust_external element has been
+// removed. Trust settings which are no longer configured would otherwise be
+// kept, as when they've never been managed.
+func resetRemovedTrust(client *api.API, d *schema.ResourceData, payloads []*models.ElasticsearchPayload) error {
+	var ownAccountID *string
+	for i, es := range payloads {
+		prefix := fmt.Sprintf("elasticsearch.%d.", i)
+		if !d.HasChanges(prefix+"trust_account", prefix+"trust_external") {
+			continue
+		}
+
+		if es.Settings != nil && es.Settings.Trust != nil {
+			continue
+		}
+
+		// The deployment owner is the account the default trust refers to.
+		if ownAccountID == nil {
+			res, err := util.GetDeployment(client, d.Id(), deputil.QueryParams{})
+			if err != nil {
+				return multierror.NewPrefixed("failed obtaining the deployment trust defaults", err)
+			}
+
+			ownAccountID = new(string)
+			if res.Metadata != nil {
+				*ownAccountID = res.Metadata.OwnerID
+			}
+		}
+
+		elasticsearchstate.ResetTrust(es, *ownAccountID)
+	}
+	return nil
+}
+
+// localAttributes are only used by the provider and not sent to the API.
+var localAttributes = map[string]bool{
+	"adopt_version_upgrades": true,
+	"wait_for_completion":    true,
+	"wait_for_healthy":       true,
+}
+
+// localElasticsearchAttributes aren't part of the Elasticsearch plan, they're
+// either set through other APIs or only used when the deployment is created.
+var localElasticsearchAttributes = map[string]bool{
+	"keystore_contents": true,
+	"remote_cluster":    true,
+	"snapshot_source":   true,
+}
+
 // hasDeploymentChange checks if there's any change in the resource attributes
-// except in the "traffic_filter" prefixed keys. If so, it returns true.
+// except in the "traffic_filter" prefixed keys and the local only attributes.
+// If so, it returns true. Each key is checked on its own, since blocks which
+// hold sets never compare as equal when read as a whole.
 func hasDeploymentChange(d *schema.ResourceData) bool {
 	for attr := range d.State().Attributes {
-		if strings.HasPrefix(attr, "traffic_filter") {
+		if strings.HasPrefix(attr, "traffic_filter") || isLocalAttribute(attr) {
 			continue
 		}
 		// Check if any of the resource attributes has a change.
@@ -86,3 +217,40 @@ func hasDeploymentChange(d *schema.ResourceData) bool {
 	}
 	return false
 }
+
+// isLocalAttribute returns true when the flattened state key belongs to a
+// local attribute or to a local attribute of the elasticsearch block.
+func isLocalAttribute(key string) bool {
+	parts := strings.SplitN(key, ".", 4)
+	if localAttributes[parts[0]] {
+		return true
+	}
+	return parts[0] == "elasticsearch" && len(parts) > 2 &&
+		localElasticsearchAttributes[parts[2]]
+}
+
+// waitForInitialPlan waits for the deployment's initial plan to finish when
+// it's still being created, e.g. after its create timed out. Unlike
+// waitForPlanCompletionContext, the plan isn't cancelled when the context is
+// done first.
+func waitForInitialPlan(ctx context.Context, client *api.API, id string) error {
+	res, err := util.GetDeployment(client, id, deputil.QueryParams{ShowPlans: true})
+	if err != nil {
+		return err
+	}
+
+	if !isInitialPlanPending(res) {
+		return nil
+	}
+
+	log.Printf("[INFO] deployment %s is still being created, waiting for its plan to finish", id)
+	errCh := make(chan error, 1)
+	go func() { errCh <- WaitForPlanCompletion(client, id) }()
+
+	select {
+	case err := <-errCh:
+		return err
+	case <-ctx.Done():
+		return fmt.Errorf("stopped waiting for the deployment's initial plan to finish: %w", ctx.Err())
+	}
+}
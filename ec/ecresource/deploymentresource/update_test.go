@@ -18,10 +18,16 @@
 package deploymentresource
 
 import (
+	"context"
+	"errors"
 	"testing"
 
+	"github.com/elastic/cloud-sdk-go/pkg/api"
 	"github.com/elastic/cloud-sdk-go/pkg/api/mock"
+	"github.com/elastic/cloud-sdk-go/pkg/models"
+	"github.com/elastic/cloud-sdk-go/pkg/util/ec"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
 	"github.com/stretchr/testify/assert"
 )
 
@@ -38,6 +44,13 @@ func Test_hasDeploymentChange(t *testing.T) {
 		},
 	})
 
+	changesToWaitForCompletion := newResourceData(t, resDataParams{
+		ID: mock.ValidClusterID,
+		Resources: map[string]interface{}{
+			"wait_for_completion": false,
+		},
+	})
+
 	changesToName := newResourceData(t, resDataParams{
 		ID:        mock.ValidClusterID,
 		Resources: map[string]interface{}{"name": "some name"},
@@ -69,6 +82,11 @@ func Test_hasDeploymentChange(t *testing.T) {
 			args: args{d: changesToTrafficFilter},
 			want: false,
 		},
+		{
+			name: "when a new resource has some changes in wait_for_completion",
+			args: args{d: changesToWaitForCompletion},
+			want: false,
+		},
 		{
 			name: "when a new resource is has some changes in name",
 			args: args{d: changesToName},
@@ -87,3 +105,132 @@ func Test_hasDeploymentChange(t *testing.T) {
 		})
 	}
 }
+
+func Test_isLocalAttribute(t *testing.T) {
+	tests := []struct {
+		name string
+		key  string
+		want bool
+	}{
+		{
+			name: "a local attribute",
+			key:  "wait_for_completion",
+			want: true,
+		},
+		{
+			name: "a local elasticsearch attribute",
+			key:  "elasticsearch.0.keystore_contents.%",
+			want: true,
+		},
+		{
+			name: "a nested key of a local elasticsearch attribute",
+			key:  "elasticsearch.0.snapshot_source.0.snapshot_name",
+			want: true,
+		},
+		{
+			name: "an elasticsearch attribute",
+			key:  "elasticsearch.0.topology.0.zone_count",
+			want: false,
+		},
+		{
+			name: "the elasticsearch block count",
+			key:  "elasticsearch.#",
+			want: false,
+		},
+		{
+			name: "a deployment attribute",
+			key:  "name",
+			want: false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, isLocalAttribute(tt.key))
+		})
+	}
+}
+
+func Test_resetRemovedTrust(t *testing.T) {
+	withTrust := newSampleDeployment()
+	es := newElasticsearchSample()
+	es["trust_account"] = []interface{}{map[string]interface{}{
+		"account_id": "some-account",
+		"trust_all":  true,
+	}}
+	withTrust["elasticsearch"] = []interface{}{es}
+
+	// The trust_account block is removed from the configuration.
+	sm := schema.InternalMap(NewSchema())
+	state := newResourceData(t, resDataParams{
+		ID:        mock.ValidClusterID,
+		Resources: withTrust,
+	}).State()
+	diff, err := sm.Diff(context.Background(), state,
+		terraform.NewResourceConfigRaw(newSampleDeployment()), nil, nil, true,
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+	removedTrust, err := sm.Data(state, diff)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	unmanagedTrust := Resource().Data(newResourceData(t, resDataParams{
+		ID:        mock.ValidClusterID,
+		Resources: newSampleDeployment(),
+	}).State())
+
+	owned := &models.DeploymentGetResponse{
+		ID:       &mock.ValidClusterID,
+		Metadata: &models.DeploymentMetadata{OwnerID: "own-account"},
+	}
+
+	tests := []struct {
+		name   string
+		d      *schema.ResourceData
+		client *api.API
+		want   *models.ElasticsearchClusterTrustSettings
+		err    error
+	}{
+		{
+			name:   "restores the own account trust when the last trust block is removed",
+			d:      removedTrust,
+			client: api.NewMock(mock.New200StructResponse(owned)),
+			want: &models.ElasticsearchClusterTrustSettings{
+				Accounts: []*models.AccountTrustRelationship{{
+					AccountID: ec.String("own-account"),
+					TrustAll:  ec.Bool(true),
+				}},
+				External: []*models.ExternalTrustRelationship{},
+			},
+		},
+		{
+			name:   "returns an error when the deployment can't be obtained",
+			d:      removedTrust,
+			client: api.NewMock(mock.SampleInternalError()),
+			err:    errors.New("failed obtaining the deployment trust defaults: 1 error occurred:\n\t* api error: internal.server.error: There was an internal server error\n\n"),
+		},
+		{
+			name:   "keeps the deployment trust settings when they're not managed",
+			d:      unmanagedTrust,
+			client: api.NewMock(),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req, err := ExpandUpdateRequest(tt.d)
+			if !assert.NoError(t, err) {
+				return
+			}
+
+			err = resetRemovedTrust(tt.client, tt.d, req.Resources.Elasticsearch)
+			if tt.err != nil {
+				assert.EqualError(t, err, tt.err.Error())
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tt.want, req.Resources.Elasticsearch[0].Settings.Trust)
+		})
+	}
+}
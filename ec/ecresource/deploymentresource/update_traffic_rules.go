@@ -18,25 +18,26 @@
 package deploymentresource
 
 import (
-	"github.com/elastic/cloud-sdk-go/pkg/api"
 	"github.com/elastic/cloud-sdk-go/pkg/api/deploymentapi/trafficfilterapi"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+
+	"github.com/elastic/terraform-provider-ec/ec/util"
 )
 
-func handleTrafficFilterChange(d *schema.ResourceData, client *api.API) error {
+func handleTrafficFilterChange(d *schema.ResourceData, providerMeta *util.ProviderMeta) error {
 	if !d.HasChange("traffic_filter") {
 		return nil
 	}
 
 	var additions, deletions = getChange(d.GetChange("traffic_filter"))
 	for _, ruleID := range additions.List() {
-		if err := associateRule(ruleID.(string), d.Id(), client); err != nil {
+		if err := associateRule(ruleID.(string), d.Id(), providerMeta); err != nil {
 			return err
 		}
 	}
 
 	for _, ruleID := range deletions.List() {
-		if err := removeRule(ruleID.(string), d.Id(), client); err != nil {
+		if err := removeRule(ruleID.(string), d.Id(), providerMeta); err != nil {
 			return err
 		}
 	}
@@ -44,6 +45,23 @@ func handleTrafficFilterChange(d *schema.ResourceData, client *api.API) error {
 	return nil
 }
 
+// forgetTrafficFilters removes the deployment's prior and planned rulesets
+// from the memoized traffic filters, since creating, updating or shutting down
+// the deployment changes their associations.
+func forgetTrafficFilters(d *schema.ResourceData, providerMeta *util.ProviderMeta) {
+	prior, planned := d.GetChange("traffic_filter")
+	for _, raw := range []interface{}{prior, planned} {
+		rulesets, ok := raw.(*schema.Set)
+		if !ok {
+			continue
+		}
+
+		for _, id := range rulesets.List() {
+			providerMeta.ForgetTrafficFilter(id.(string))
+		}
+	}
+}
+
 func getChange(oldInterface, newInterface interface{}) (add, delete *schema.Set) {
 	var old, new *schema.Set
 	if s, ok := oldInterface.(*schema.Set); ok {
@@ -59,10 +77,8 @@ func getChange(oldInterface, newInterface interface{}) (add, delete *schema.Set)
 	return add, delete
 }
 
-func associateRule(ruleID, deploymentID string, client *api.API) error {
-	res, err := trafficfilterapi.Get(trafficfilterapi.GetParams{
-		API: client, ID: ruleID, IncludeAssociations: true,
-	})
+func associateRule(ruleID, deploymentID string, providerMeta *util.ProviderMeta) error {
+	res, err := providerMeta.GetTrafficFilter(ruleID)
 	if err != nil {
 		return err
 	}
@@ -75,18 +91,17 @@ func associateRule(ruleID, deploymentID string, client *api.API) error {
 	}
 
 	// Create assignment.
+	defer providerMeta.ForgetTrafficFilter(ruleID)
 	if err := trafficfilterapi.CreateAssociation(trafficfilterapi.CreateAssociationParams{
-		API: client, ID: ruleID, EntityType: "deployment", EntityID: deploymentID,
+		API: providerMeta.API, ID: ruleID, EntityType: "deployment", EntityID: deploymentID,
 	}); err != nil {
 		return err
 	}
 	return nil
 }
 
-func removeRule(ruleID, deploymentID string, client *api.API) error {
-	res, err := trafficfilterapi.Get(trafficfilterapi.GetParams{
-		API: client, ID: ruleID, IncludeAssociations: true,
-	})
+func removeRule(ruleID, deploymentID string, providerMeta *util.ProviderMeta) error {
+	res, err := providerMeta.GetTrafficFilter(ruleID)
 
 	// Removal is a little bit more hairy, the rule might have already been
 	// destroyed and the associated Traffic Filter associations too, so if an
@@ -94,7 +109,7 @@ func removeRule(ruleID, deploymentID string, client *api.API) error {
 	// existing rules sets since the GET <rule id> returned with an error.
 	// If the rule set doesn't exist, then nil is returned.
 	if err != nil {
-		r, e := trafficfilterapi.List(trafficfilterapi.ListParams{API: client})
+		r, e := trafficfilterapi.List(trafficfilterapi.ListParams{API: providerMeta.API})
 		if e != nil {
 			return e
 		}
@@ -109,8 +124,9 @@ func removeRule(ruleID, deploymentID string, client *api.API) error {
 	// If the rule is found, then delete the association.
 	for _, assoc := range res.Associations {
 		if deploymentID == *assoc.ID {
+			defer providerMeta.ForgetTrafficFilter(ruleID)
 			return trafficfilterapi.DeleteAssociation(trafficfilterapi.DeleteAssociationParams{
-				API:        client,
+				API:        providerMeta.API,
 				ID:         ruleID,
 				EntityID:   *assoc.ID,
 				EntityType: *assoc.EntityType,
@@ -0,0 +1,66 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package deploymentresource
+
+import (
+	"github.com/blang/semver/v4"
+	"github.com/elastic/cloud-sdk-go/pkg/models"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+
+	"github.com/elastic/terraform-provider-ec/ec/ecresource/deploymentresource/elasticsearchstate"
+)
+
+// getDeploymentVersion returns the Elastic Stack version of the deployment's
+// Elasticsearch resource current plan. Returns an empty string when there's no
+// current plan.
+func getDeploymentVersion(res *models.DeploymentResources) string {
+	for _, esRes := range res.Elasticsearch {
+		if elasticsearchstate.IsCurrentPlanEmpty(esRes) {
+			continue
+		}
+
+		if es := esRes.Info.PlanInfo.Current.Plan.Elasticsearch; es != nil && es.Version != "" {
+			return es.Version
+		}
+	}
+	return ""
+}
+
+// suppressPatchDowngrade suppresses the "version" diff when the deployment
+// has been upgraded to a newer patch version outside of Terraform, so the
+// upgraded version is adopted rather than planning a downgrade which would be
+// rejected by the API. It's only enabled when "adopt_version_upgrades" is set.
+func suppressPatchDowngrade(_, old, new string, d *schema.ResourceData) bool {
+	if !d.Get("adopt_version_upgrades").(bool) {
+		return false
+	}
+
+	current, err := semver.Parse(old)
+	if err != nil {
+		return false
+	}
+
+	configured, err := semver.Parse(new)
+	if err != nil {
+		return false
+	}
+
+	return current.Major == configured.Major &&
+		current.Minor == configured.Minor &&
+		current.GT(configured)
+}
@@ -0,0 +1,126 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package deploymentresource
+
+import (
+	"testing"
+
+	"github.com/elastic/cloud-sdk-go/pkg/models"
+	"github.com/elastic/cloud-sdk-go/pkg/util/ec"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_getDeploymentVersion(t *testing.T) {
+	type args struct {
+		res *models.DeploymentResources
+	}
+	tests := []struct {
+		name string
+		args args
+		want string
+	}{
+		{
+			name: "returns an empty version when there's no current plan",
+			args: args{res: &models.DeploymentResources{
+				Elasticsearch: []*models.ElasticsearchResourceInfo{{
+					RefID: ec.String("main-elasticsearch"),
+					Info: &models.ElasticsearchClusterInfo{
+						PlanInfo: &models.ElasticsearchClusterPlansInfo{},
+					},
+				}},
+			}},
+		},
+		{
+			name: "returns the current plan version",
+			args: args{res: &models.DeploymentResources{
+				Elasticsearch: []*models.ElasticsearchResourceInfo{{
+					RefID: ec.String("main-elasticsearch"),
+					Info: &models.ElasticsearchClusterInfo{
+						PlanInfo: &models.ElasticsearchClusterPlansInfo{
+							Current: &models.ElasticsearchClusterPlanInfo{
+								Plan: &models.ElasticsearchClusterPlan{
+									Elasticsearch: &models.ElasticsearchConfiguration{
+										Version: "7.9.2",
+									},
+								},
+							},
+						},
+					},
+				}},
+			}},
+			want: "7.9.2",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := getDeploymentVersion(tt.args.res)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func Test_suppressPatchDowngrade(t *testing.T) {
+	adopt := schema.TestResourceDataRaw(t, NewSchema(), nil)
+	dontAdopt := schema.TestResourceDataRaw(t, NewSchema(), map[string]interface{}{
+		"adopt_version_upgrades": false,
+	})
+
+	type args struct {
+		old string
+		new string
+		d   *schema.ResourceData
+	}
+	tests := []struct {
+		name string
+		args args
+		want bool
+	}{
+		{
+			name: "suppresses the diff when the deployment has a newer patch version",
+			args: args{old: "7.9.2", new: "7.9.0", d: adopt},
+			want: true,
+		},
+		{
+			name: "doesn't suppress the diff when adopting upgrades is disabled",
+			args: args{old: "7.9.2", new: "7.9.0", d: dontAdopt},
+			want: false,
+		},
+		{
+			name: "doesn't suppress the diff on a configured upgrade",
+			args: args{old: "7.9.0", new: "7.9.2", d: adopt},
+			want: false,
+		},
+		{
+			name: "doesn't suppress the diff when the minor version differs",
+			args: args{old: "7.10.0", new: "7.9.2", d: adopt},
+			want: false,
+		},
+		{
+			name: "doesn't suppress the diff when there's no previous version",
+			args: args{old: "", new: "7.9.2", d: adopt},
+			want: false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := suppressPatchDowngrade("version", tt.args.old, tt.args.new, tt.args.d)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
@@ -18,11 +18,22 @@
 package deploymentresource
 
 import (
+	"context"
+	"errors"
+	"fmt"
 	"time"
 
 	"github.com/elastic/cloud-sdk-go/pkg/api"
+	"github.com/elastic/cloud-sdk-go/pkg/api/deploymentapi"
+	"github.com/elastic/cloud-sdk-go/pkg/api/deploymentapi/depresourceapi"
+	"github.com/elastic/cloud-sdk-go/pkg/api/deploymentapi/deputil"
+	"github.com/elastic/cloud-sdk-go/pkg/models"
+	"github.com/elastic/cloud-sdk-go/pkg/multierror"
 	"github.com/elastic/cloud-sdk-go/pkg/plan"
 	"github.com/elastic/cloud-sdk-go/pkg/plan/planutil"
+	"github.com/elastic/cloud-sdk-go/pkg/util"
+
+	"github.com/elastic/terraform-provider-ec/ec/ecresource/deploymentresource/elasticsearchstate"
 )
 
 const (
@@ -30,6 +41,10 @@ const (
 	defaultMaxRetry      = 5
 )
 
+// errPlanStillRunning is returned when a create times out while the
+// deployment's initial plan is still running.
+var errPlanStillRunning = errors.New("timed out waiting for the deployment plan to finish")
+
 // WaitForPlanCompletion waits for a pending plan to finish.
 func WaitForPlanCompletion(client *api.API, id string) error {
 	return planutil.Wait(plan.TrackChangeParams{
@@ -40,3 +55,124 @@ func WaitForPlanCompletion(client *api.API, id string) error {
 		},
 	})
 }
+
+// waitForPlanCompletionContext waits for a pending plan to finish. When the
+// context is cancelled first (the operation was interrupted or timed out),
+// the deployment's pending plans are cancelled so no half applied changes are
+// left running on the server side.
+func waitForPlanCompletionContext(ctx context.Context, client *api.API, id string) error {
+	errCh := make(chan error, 1)
+	go func() { errCh <- WaitForPlanCompletion(client, id) }()
+
+	select {
+	case err := <-errCh:
+		return err
+	case <-ctx.Done():
+		return interrupted(ctx, client, id)
+	}
+}
+
+// waitForCreatePlanCompletion waits for the deployment's initial plan to
+// finish. Unlike waitForPlanCompletionContext, the plan isn't cancelled when
+// the create times out: errPlanStillRunning is returned instead, so the
+// deployment can be kept in the state and the next refresh re-attaches to the
+// plan rather than creating another deployment.
+func waitForCreatePlanCompletion(ctx context.Context, client *api.API, id string) error {
+	errCh := make(chan error, 1)
+	go func() { errCh <- WaitForPlanCompletion(client, id) }()
+
+	select {
+	case err := <-errCh:
+		return err
+	case <-ctx.Done():
+		if errors.Is(ctx.Err(), context.DeadlineExceeded) {
+			return errPlanStillRunning
+		}
+		return interrupted(ctx, client, id)
+	}
+}
+
+// interrupted cancels the deployment's pending plans after the context is
+// done and returns the error describing the outcome.
+func interrupted(ctx context.Context, client *api.API, id string) error {
+	if err := cancelPendingPlans(client, id); err != nil {
+		return multierror.NewPrefixed(
+			"operation interrupted, failed cancelling the pending plans", ctx.Err(), err,
+		)
+	}
+	return fmt.Errorf("operation interrupted, the pending plans were cancelled: %w", ctx.Err())
+}
+
+// isInitialPlanPending returns true when the deployment is still being created,
+// that is, its Elasticsearch resources have a pending plan but no current one.
+func isInitialPlanPending(res *models.DeploymentGetResponse) bool {
+	if res == nil || res.Resources == nil || len(res.Resources.Elasticsearch) == 0 {
+		return false
+	}
+
+	for _, r := range res.Resources.Elasticsearch {
+		if !elasticsearchstate.IsCurrentPlanEmpty(r) {
+			return false
+		}
+		if r.Info == nil || r.Info.PlanInfo == nil || r.Info.PlanInfo.Pending == nil {
+			return false
+		}
+	}
+
+	return true
+}
+
+// cancelPendingPlans cancels the pending plans of all the deployment resources.
+func cancelPendingPlans(client *api.API, id string) error {
+	res, err := deploymentapi.Get(deploymentapi.GetParams{
+		API: client, DeploymentID: id,
+		QueryParams: deputil.QueryParams{ShowPlans: true},
+	})
+	if err != nil {
+		return err
+	}
+
+	var merr = multierror.NewPrefixed("failed cancelling pending plan")
+	for _, params := range pendingPlans(res) {
+		params.API, params.DeploymentID = client, id
+		if _, err := depresourceapi.CancelPlan(depresourceapi.CancelPlanParams{
+			Params: params,
+		}); err != nil {
+			merr = merr.Append(fmt.Errorf("%s %s: %w", params.Kind, params.RefID, err))
+		}
+	}
+
+	return merr.ErrorOrNil()
+}
+
+// pendingPlans returns the kind and ref_id of the deployment resources which
+// have a pending plan.
+func pendingPlans(res *models.DeploymentGetResponse) []depresourceapi.Params {
+	var pending []depresourceapi.Params
+	if res == nil || res.Resources == nil {
+		return pending
+	}
+
+	for _, r := range res.Resources.Elasticsearch {
+		if r.Info != nil && r.Info.PlanInfo != nil && r.Info.PlanInfo.Pending != nil {
+			pending = append(pending, depresourceapi.Params{Kind: util.Elasticsearch, RefID: *r.RefID})
+		}
+	}
+	for _, r := range res.Resources.Kibana {
+		if r.Info != nil && r.Info.PlanInfo != nil && r.Info.PlanInfo.Pending != nil {
+			pending = append(pending, depresourceapi.Params{Kind: util.Kibana, RefID: *r.RefID})
+		}
+	}
+	for _, r := range res.Resources.Apm {
+		if r.Info != nil && r.Info.PlanInfo != nil && r.Info.PlanInfo.Pending != nil {
+			pending = append(pending, depresourceapi.Params{Kind: util.Apm, RefID: *r.RefID})
+		}
+	}
+	for _, r := range res.Resources.EnterpriseSearch {
+		if r.Info != nil && r.Info.PlanInfo != nil && r.Info.PlanInfo.Pending != nil {
+			pending = append(pending, depresourceapi.Params{Kind: util.EnterpriseSearch, RefID: *r.RefID})
+		}
+	}
+
+	return pending
+}
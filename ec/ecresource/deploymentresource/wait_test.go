@@ -0,0 +1,112 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package deploymentresource
+
+import (
+	"testing"
+
+	"github.com/elastic/cloud-sdk-go/pkg/api/deploymentapi/depresourceapi"
+	"github.com/elastic/cloud-sdk-go/pkg/models"
+	"github.com/elastic/cloud-sdk-go/pkg/util/ec"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_pendingPlans(t *testing.T) {
+	tests := []struct {
+		name string
+		res  *models.DeploymentGetResponse
+		want []depresourceapi.Params
+	}{
+		{
+			name: "nil response returns no pending plans",
+		},
+		{
+			name: "returns the resources with a pending plan",
+			res: &models.DeploymentGetResponse{Resources: &models.DeploymentResources{
+				Elasticsearch: []*models.ElasticsearchResourceInfo{{
+					RefID: ec.String("main-elasticsearch"),
+					Info: &models.ElasticsearchClusterInfo{PlanInfo: &models.ElasticsearchClusterPlansInfo{
+						Pending: &models.ElasticsearchClusterPlanInfo{},
+					}},
+				}},
+				Kibana: []*models.KibanaResourceInfo{{
+					RefID: ec.String("main-kibana"),
+					Info: &models.KibanaClusterInfo{PlanInfo: &models.KibanaClusterPlansInfo{
+						Current: &models.KibanaClusterPlanInfo{},
+					}},
+				}},
+				Apm: []*models.ApmResourceInfo{{
+					RefID: ec.String("main-apm"),
+					Info: &models.ApmInfo{PlanInfo: &models.ApmPlansInfo{
+						Pending: &models.ApmPlanInfo{},
+					}},
+				}},
+			}},
+			want: []depresourceapi.Params{
+				{Kind: "elasticsearch", RefID: "main-elasticsearch"},
+				{Kind: "apm", RefID: "main-apm"},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, pendingPlans(tt.res))
+		})
+	}
+}
+
+func Test_isInitialPlanPending(t *testing.T) {
+	tests := []struct {
+		name string
+		res  *models.DeploymentGetResponse
+		want bool
+	}{
+		{
+			name: "nil response has no pending initial plan",
+		},
+		{
+			name: "a created deployment with a pending plan has no pending initial plan",
+			res: &models.DeploymentGetResponse{Resources: &models.DeploymentResources{
+				Elasticsearch: []*models.ElasticsearchResourceInfo{{
+					RefID: ec.String("main-elasticsearch"),
+					Info: &models.ElasticsearchClusterInfo{PlanInfo: &models.ElasticsearchClusterPlansInfo{
+						Current: &models.ElasticsearchClusterPlanInfo{Plan: &models.ElasticsearchClusterPlan{}},
+						Pending: &models.ElasticsearchClusterPlanInfo{},
+					}},
+				}},
+			}},
+		},
+		{
+			name: "a deployment being created has a pending initial plan",
+			res: &models.DeploymentGetResponse{Resources: &models.DeploymentResources{
+				Elasticsearch: []*models.ElasticsearchResourceInfo{{
+					RefID: ec.String("main-elasticsearch"),
+					Info: &models.ElasticsearchClusterInfo{PlanInfo: &models.ElasticsearchClusterPlansInfo{
+						Pending: &models.ElasticsearchClusterPlanInfo{},
+					}},
+				}},
+			}},
+			want: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, isInitialPlanPending(tt.res))
+		})
+	}
+}
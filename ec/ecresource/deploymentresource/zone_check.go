@@ -0,0 +1,115 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package deploymentresource
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/elastic/cloud-sdk-go/pkg/api/platformapi/allocatorapi"
+	"github.com/elastic/cloud-sdk-go/pkg/multierror"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+
+	"github.com/elastic/terraform-provider-ec/ec/util"
+)
+
+// essZones is the number of availability zones of the Elasticsearch Service
+// regions.
+const essZones = 3
+
+// checkZoneCount verifies that the topology elements don't span more zones
+// than the region has, so the plan fails with a clear error rather than the
+// API rejecting the request. Existing deployments are only checked when a
+// topology changes. In ECE, the zones are the ones with allocators, and the
+// check is skipped when the allocators can't be listed.
+func checkZoneCount(_ context.Context, d *schema.ResourceDiff, meta interface{}) error {
+	var declared [4][]int
+	var maxZones int
+	for i, kind := range topologyKinds {
+		if d.Id() != "" && !d.HasChange(kind) {
+			continue
+		}
+
+		if d.Get(kind+".#").(int) == 0 {
+			continue
+		}
+
+		for _, raw := range d.Get(kind + ".0.topology").([]interface{}) {
+			var zones int
+			if topology, ok := raw.(map[string]interface{}); ok {
+				zones, _ = topology["zone_count"].(int)
+			}
+			declared[i] = append(declared[i], zones)
+			if zones > maxZones {
+				maxZones = zones
+			}
+		}
+	}
+
+	// Every region has at least one zone, so the region is only looked up
+	// when an element spans more.
+	if maxZones <= 1 || !d.NewValueKnown("region") {
+		return nil
+	}
+
+	providerMeta := meta.(*util.ProviderMeta)
+	client := providerMeta.API
+	region := d.Get("region").(string)
+	var available = essZones
+	if providerMeta.PlatformVersion != "" {
+		res, err := allocatorapi.List(allocatorapi.ListParams{
+			API:    client,
+			Region: region,
+		})
+		if err != nil {
+			return nil
+		}
+
+		available = 0
+		for _, zone := range res.Zones {
+			if zone != nil && len(zone.Allocators) > 0 {
+				available++
+			}
+		}
+
+		if available == 0 {
+			return nil
+		}
+	}
+
+	return tooManyZones(region, available, declared)
+}
+
+// tooManyZones returns an error naming the topology elements which span more
+// zones than the available ones.
+func tooManyZones(region string, available int, declared [4][]int) error {
+	var merr = multierror.NewPrefixed("invalid zone_count")
+	for i, elems := range declared {
+		for j, zones := range elems {
+			if zones <= available {
+				continue
+			}
+			merr = merr.Append(fmt.Errorf(
+				`%s topology.%d: %d zones requested, but the "%s" region only has %d availability zones`,
+				topologyKinds[i], j, zones, region, available,
+			))
+		}
+	}
+
+	return merr.ErrorOrNil()
+}
@@ -0,0 +1,65 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package deploymentresource
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/elastic/cloud-sdk-go/pkg/multierror"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_tooManyZones(t *testing.T) {
+	type args struct {
+		available int
+		declared  [4][]int
+	}
+	tests := []struct {
+		name string
+		args args
+		err  error
+	}{
+		{
+			name: "all the topology elements fit in the region",
+			args: args{available: 3, declared: [4][]int{{3, 1}, {2}, {1}}},
+		},
+		{
+			name: "unset zone counts are ignored",
+			args: args{available: 1, declared: [4][]int{{0}}},
+		},
+		{
+			name: "returns the topology elements which span too many zones",
+			args: args{available: 2, declared: [4][]int{{2, 3}, {1}, nil, {3}}},
+			err: multierror.NewPrefixed("invalid zone_count",
+				errors.New(`elasticsearch topology.1: 3 zones requested, but the "ece-region" region only has 2 availability zones`),
+				errors.New(`enterprise_search topology.0: 3 zones requested, but the "ece-region" region only has 2 availability zones`),
+			),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tooManyZones("ece-region", tt.args.available, tt.args.declared)
+			if tt.err != nil {
+				assert.EqualError(t, err, tt.err.Error())
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
@@ -0,0 +1,44 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package extensionresource
+
+import (
+	"github.com/elastic/cloud-sdk-go/pkg/models"
+	"github.com/elastic/cloud-sdk-go/pkg/util/ec"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func expandCreateModel(d *schema.ResourceData) *models.CreateExtensionRequest {
+	return &models.CreateExtensionRequest{
+		Name:          ec.String(d.Get("name").(string)),
+		ExtensionType: ec.String(d.Get("extension_type").(string)),
+		Version:       ec.String(d.Get("version").(string)),
+		Description:   d.Get("description").(string),
+		DownloadURL:   d.Get("download_url").(string),
+	}
+}
+
+func expandUpdateModel(d *schema.ResourceData) *models.UpdateExtensionRequest {
+	return &models.UpdateExtensionRequest{
+		Name:          ec.String(d.Get("name").(string)),
+		ExtensionType: ec.String(d.Get("extension_type").(string)),
+		Version:       ec.String(d.Get("version").(string)),
+		Description:   d.Get("description").(string),
+		DownloadURL:   d.Get("download_url").(string),
+	}
+}
@@ -0,0 +1,55 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package extensionresource
+
+import (
+	"github.com/elastic/cloud-sdk-go/pkg/models"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func modelToState(d *schema.ResourceData, res *models.Extension) error {
+	if err := d.Set("name", *res.Name); err != nil {
+		return err
+	}
+
+	if err := d.Set("extension_type", *res.ExtensionType); err != nil {
+		return err
+	}
+
+	if err := d.Set("version", *res.Version); err != nil {
+		return err
+	}
+
+	if err := d.Set("url", *res.URL); err != nil {
+		return err
+	}
+
+	if res.Description != "" {
+		if err := d.Set("description", res.Description); err != nil {
+			return err
+		}
+	}
+
+	if res.DownloadURL != "" {
+		if err := d.Set("download_url", res.DownloadURL); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,77 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package extensionresource
+
+import (
+	"testing"
+
+	"github.com/elastic/cloud-sdk-go/pkg/models"
+	"github.com/elastic/cloud-sdk-go/pkg/util/ec"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_modelToState(t *testing.T) {
+	extensionSchemaArg := schema.TestResourceDataRaw(t, NewSchema(), nil)
+	extensionSchemaArg.SetId("some-random-id")
+
+	remoteState := models.Extension{
+		ID:            ec.String("some-random-id"),
+		Name:          ec.String("my_extension"),
+		ExtensionType: ec.String("bundle"),
+		Version:       ec.String("7.*"),
+		Description:   "my custom synonyms",
+		DownloadURL:   "https://example.com/synonyms.zip",
+		URL:           ec.String("repo://1234"),
+	}
+
+	var sample = newSampleExtension()
+	sample["url"] = "repo://1234"
+	wantExtension := newResourceData(t, resDataParams{
+		ID:        "some-random-id",
+		Resources: sample,
+	})
+	type args struct {
+		d   *schema.ResourceData
+		res *models.Extension
+	}
+	tests := []struct {
+		name string
+		args args
+		err  error
+		want *schema.ResourceData
+	}{
+		{
+			name: "flattens the resource",
+			args: args{d: extensionSchemaArg, res: &remoteState},
+			want: wantExtension,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := modelToState(tt.args.d, tt.args.res)
+			if tt.err != nil {
+				assert.EqualError(t, err, tt.err.Error())
+			} else {
+				assert.NoError(t, err)
+			}
+
+			assert.Equal(t, tt.want.State().Attributes, tt.args.d.State().Attributes)
+		})
+	}
+}
@@ -0,0 +1,74 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package extensionresource
+
+import (
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+// NewSchema returns the schema for an "ec_deployment_extension" resource.
+func NewSchema() map[string]*schema.Schema {
+	return map[string]*schema.Schema{
+		"name": {
+			Type:        schema.TypeString,
+			Description: "Required name of the extension",
+			Required:    true,
+		},
+		"extension_type": {
+			Type:         schema.TypeString,
+			Description:  `Required extension type ("bundle" or "plugin")`,
+			Required:     true,
+			ForceNew:     true,
+			ValidateFunc: validation.StringInSlice([]string{"bundle", "plugin"}, false),
+		},
+		"version": {
+			Type:        schema.TypeString,
+			Description: `Required Elastic Stack version the extension is compatible with, plugins must match the exact version while bundles accept wildcards (e.g. "7.*")`,
+			Required:    true,
+		},
+		"description": {
+			Type:        schema.TypeString,
+			Description: "Optional description of the extension",
+			Optional:    true,
+		},
+		"download_url": {
+			Type:          schema.TypeString,
+			Description:   "Optional URL to download the extension archive from",
+			Optional:      true,
+			ConflictsWith: []string{"file_path"},
+		},
+		"file_path": {
+			Type:        schema.TypeString,
+			Description: "Optional path of the extension archive to upload, which is uploaded again when its contents change",
+			Optional:    true,
+		},
+
+		// Computed attributes
+		"url": {
+			Type:        schema.TypeString,
+			Description: "Computed extension URL, to be used in the deployment plan",
+			Computed:    true,
+		},
+		"file_hash": {
+			Type:        schema.TypeString,
+			Description: "Computed SHA-256 hash of the last uploaded extension archive",
+			Computed:    true,
+		},
+	}
+}
@@ -0,0 +1,95 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package extensionresource
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/elastic/cloud-sdk-go/pkg/api"
+	"github.com/elastic/cloud-sdk-go/pkg/client/extensions"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+// checkFileHash sets the new "file_hash" when the contents of the file set in
+// "file_path" have changed since the last upload, so the file is uploaded
+// again on apply.
+func checkFileHash(_ context.Context, d *schema.ResourceDiff, _ interface{}) error {
+	path := d.Get("file_path").(string)
+	if path == "" || !d.NewValueKnown("file_path") {
+		return nil
+	}
+
+	hash, err := fileHash(path)
+	if err != nil {
+		return err
+	}
+
+	if hash != d.Get("file_hash").(string) {
+		return d.SetNew("file_hash", hash)
+	}
+
+	return nil
+}
+
+// fileHash returns the hex encoded SHA-256 of the file contents.
+func fileHash(path string) (string, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return "", fmt.Errorf("failed reading the extension file: %w", err)
+	}
+	defer f.Close()
+
+	h := sha256.New()
+	if _, err := io.Copy(h, f); err != nil {
+		return "", fmt.Errorf("failed reading the extension file: %w", err)
+	}
+
+	return hex.EncodeToString(h.Sum(nil)), nil
+}
+
+// uploadFile uploads the file set in "file_path" to the extension and stores
+// the hash of its contents in "file_hash".
+func uploadFile(client *api.API, d *schema.ResourceData) error {
+	path := d.Get("file_path").(string)
+	hash, err := fileHash(path)
+	if err != nil {
+		return err
+	}
+
+	f, err := os.Open(path)
+	if err != nil {
+		return fmt.Errorf("failed reading the extension file: %w", err)
+	}
+	defer f.Close()
+
+	if _, err := client.V1API.Extensions.UploadExtension(
+		extensions.NewUploadExtensionParams().
+			WithExtensionID(d.Id()).
+			WithFile(f),
+		client.AuthWriter,
+	); err != nil {
+		return api.UnwrapError(err)
+	}
+
+	return d.Set("file_hash", hash)
+}
@@ -0,0 +1,57 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package keystoreresource
+
+import (
+	"context"
+	"strconv"
+	"strings"
+
+	"github.com/elastic/cloud-sdk-go/pkg/api/deploymentapi/eskeystoreapi"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+
+	"github.com/elastic/terraform-provider-ec/ec/util"
+)
+
+// create will write a new setting to the deployment's Elasticsearch keystore.
+func create(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	var client = meta.(*util.ProviderMeta).API
+	deploymentID := d.Get("deployment_id").(string)
+	defer util.LockDeployment(deploymentID)()
+
+	contents, err := expandModel(d)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	if _, err := eskeystoreapi.Update(eskeystoreapi.UpdateParams{
+		API:          client,
+		DeploymentID: deploymentID,
+		Contents:     contents,
+	}); err != nil {
+		return util.ErrorDiagnostics(err)
+	}
+
+	d.SetId(hashID(deploymentID, d.Get("setting_name").(string)))
+	return read(ctx, d, meta)
+}
+
+func hashID(elem ...string) string {
+	return strconv.Itoa(schema.HashString(strings.Join(elem, "-")))
+}
@@ -0,0 +1,74 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package keystoreresource
+
+import (
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"unicode/utf8"
+
+	"github.com/elastic/cloud-sdk-go/pkg/models"
+	"github.com/elastic/cloud-sdk-go/pkg/util/ec"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+
+	"github.com/elastic/terraform-provider-ec/ec/util"
+)
+
+// expandModel returns the partial keystore contents which write the resource's
+// setting to the Elasticsearch keystore. The value_base64 content is decoded
+// and always stored as a file.
+func expandModel(d *schema.ResourceData) (*models.KeystoreContents, error) {
+	var secret = models.KeystoreSecret{
+		Value:  d.Get("value").(string),
+		AsFile: ec.Bool(d.Get("as_file").(bool)),
+	}
+
+	if encoded := d.Get("value_base64").(string); encoded != "" {
+		content, err := base64.StdEncoding.DecodeString(encoded)
+		if err != nil {
+			return nil, fmt.Errorf("failed decoding value_base64: %w", err)
+		}
+
+		// The secret value is sent as a JSON string, which can't hold binary
+		// content.
+		if !utf8.Valid(content) {
+			return nil, errors.New("value_base64: the decoded content must be valid UTF-8 text")
+		}
+
+		secret.Value = string(content)
+		secret.AsFile = ec.Bool(true)
+	}
+
+	return &models.KeystoreContents{
+		Secrets: map[string]models.KeystoreSecret{
+			d.Get("setting_name").(string): secret,
+		},
+	}, nil
+}
+
+// expandDeleteModel returns the partial keystore contents which remove the
+// resource's setting from the Elasticsearch keystore, i.e.
+// {"secrets": {"<setting_name>": null}}.
+func expandDeleteModel(d *schema.ResourceData) *util.KeystoreUpdate {
+	return &util.KeystoreUpdate{
+		Secrets: map[string]*models.KeystoreSecret{
+			d.Get("setting_name").(string): nil,
+		},
+	}
+}
@@ -0,0 +1,142 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package keystoreresource
+
+import (
+	"encoding/json"
+	"errors"
+	"testing"
+
+	"github.com/elastic/cloud-sdk-go/pkg/models"
+	"github.com/elastic/cloud-sdk-go/pkg/util/ec"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_expandModel(t *testing.T) {
+	rd := newResourceData(t, resDataParams{
+		Resources: newSampleKeystore(),
+		ID:        "123451",
+	})
+	fileSample := newSampleKeystore()
+	fileSample["as_file"] = true
+	fileRd := newResourceData(t, resDataParams{
+		Resources: fileSample,
+		ID:        "123451",
+	})
+	base64Rd := newResourceData(t, resDataParams{
+		Resources: newSampleBase64Keystore("eyJ0eXBlIjogInNlcnZpY2VfYWNjb3VudCJ9"),
+		ID:        "123451",
+	})
+	binaryRd := newResourceData(t, resDataParams{
+		Resources: newSampleBase64Keystore("//79"),
+		ID:        "123451",
+	})
+	type args struct {
+		d *schema.ResourceData
+	}
+	tests := []struct {
+		name string
+		args args
+		want *models.KeystoreContents
+		err  error
+	}{
+		{
+			name: "expands the resource data",
+			args: args{d: rd},
+			want: &models.KeystoreContents{
+				Secrets: map[string]models.KeystoreSecret{
+					"s3.client.default.access_key": {
+						Value:  "somevalue",
+						AsFile: ec.Bool(false),
+					},
+				},
+			},
+		},
+		{
+			name: "expands the resource data with as_file",
+			args: args{d: fileRd},
+			want: &models.KeystoreContents{
+				Secrets: map[string]models.KeystoreSecret{
+					"s3.client.default.access_key": {
+						Value:  "somevalue",
+						AsFile: ec.Bool(true),
+					},
+				},
+			},
+		},
+		{
+			name: "expands the resource data with value_base64 as a file",
+			args: args{d: base64Rd},
+			want: &models.KeystoreContents{
+				Secrets: map[string]models.KeystoreSecret{
+					"s3.client.default.access_key": {
+						Value:  `{"type": "service_account"}`,
+						AsFile: ec.Bool(true),
+					},
+				},
+			},
+		},
+		{
+			name: "fails on binary value_base64 content",
+			args: args{d: binaryRd},
+			err:  errors.New("value_base64: the decoded content must be valid UTF-8 text"),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := expandModel(tt.args.d)
+			if tt.err != nil {
+				assert.EqualError(t, err, tt.err.Error())
+			} else {
+				assert.NoError(t, err)
+			}
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func Test_expandDeleteModel(t *testing.T) {
+	rd := newResourceData(t, resDataParams{
+		Resources: newSampleKeystore(),
+		ID:        "123451",
+	})
+	type args struct {
+		d *schema.ResourceData
+	}
+	tests := []struct {
+		name string
+		args args
+		want string
+	}{
+		{
+			name: "expands the resource data into a null secret",
+			args: args{d: rd},
+			want: `{"secrets":{"s3.client.default.access_key":null}}`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := json.Marshal(expandDeleteModel(tt.args.d))
+			if !assert.NoError(t, err) {
+				return
+			}
+			assert.JSONEq(t, tt.want, string(got))
+		})
+	}
+}
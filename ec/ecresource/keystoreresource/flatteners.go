@@ -0,0 +1,49 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package keystoreresource
+
+import (
+	"github.com/elastic/cloud-sdk-go/pkg/models"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+// flatten updates the local state from the remote keystore contents. Since the
+// keystore values are never returned by the API, only the presence of the
+// setting and its as_file flag are read, and the resource is removed from the
+// state when the setting has been removed outside of terraform.
+func flatten(res *models.KeystoreContents, d *schema.ResourceData) error {
+	if res == nil {
+		return nil
+	}
+
+	secret, ok := res.Secrets[d.Get("setting_name").(string)]
+	if !ok {
+		d.SetId("")
+		return nil
+	}
+
+	// The value_base64 settings are always stored as files, regardless of the
+	// configured as_file.
+	if secret.AsFile != nil && d.Get("value_base64").(string) == "" {
+		if err := d.Set("as_file", *secret.AsFile); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,98 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package keystoreresource
+
+import (
+	"testing"
+
+	"github.com/elastic/cloud-sdk-go/pkg/api/mock"
+	"github.com/elastic/cloud-sdk-go/pkg/models"
+	"github.com/elastic/cloud-sdk-go/pkg/util/ec"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_flatten(t *testing.T) {
+	base64Sample := newSampleBase64Keystore("c29tZXZhbHVl")
+	tests := []struct {
+		name      string
+		resources map[string]interface{}
+		res       *models.KeystoreContents
+		wantID    string
+		want      map[string]string
+	}{
+		{
+			name:   "empty response returns nil",
+			wantID: "123451",
+		},
+		{
+			name: "flattens the response",
+			res: &models.KeystoreContents{Secrets: map[string]models.KeystoreSecret{
+				"some.other.setting":           {},
+				"s3.client.default.access_key": {AsFile: ec.Bool(true)},
+			}},
+			wantID: "123451",
+			want: map[string]string{
+				"id":            "123451",
+				"deployment_id": mock.ValidClusterID,
+				"setting_name":  "s3.client.default.access_key",
+				"value":         hashValue("somevalue"),
+				"as_file":       "true",
+			},
+		},
+		{
+			name:      "doesn't set as_file for the value_base64 settings",
+			resources: base64Sample,
+			res: &models.KeystoreContents{Secrets: map[string]models.KeystoreSecret{
+				"s3.client.default.access_key": {AsFile: ec.Bool(true)},
+			}},
+			wantID: "123451",
+			want: map[string]string{
+				"id":            "123451",
+				"deployment_id": mock.ValidClusterID,
+				"setting_name":  "s3.client.default.access_key",
+				"value_base64":  hashValue("c29tZXZhbHVl"),
+				"as_file":       "false",
+			},
+		},
+		{
+			name: "removes the resource when the setting has been removed externally",
+			res: &models.KeystoreContents{Secrets: map[string]models.KeystoreSecret{
+				"some.other.setting": {},
+			}},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			resources := tt.resources
+			if resources == nil {
+				resources = newSampleKeystore()
+			}
+			d := newResourceData(t, resDataParams{
+				Resources: resources,
+				ID:        "123451",
+			})
+			err := flatten(tt.res, d)
+			assert.NoError(t, err)
+			assert.Equal(t, tt.wantID, d.Id())
+
+			if tt.want != nil {
+				assert.Equal(t, tt.want, d.State().Attributes)
+			}
+		})
+	}
+}
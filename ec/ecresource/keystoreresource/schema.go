@@ -0,0 +1,95 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package keystoreresource
+
+import (
+	"crypto/sha256"
+	"encoding/base64"
+	"fmt"
+	"unicode/utf8"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+// newSchema returns the schema for an "ec_deployment_elasticsearch_keystore" resource.
+func newSchema() map[string]*schema.Schema {
+	return map[string]*schema.Schema{
+		"deployment_id": {
+			Type:        schema.TypeString,
+			Description: `Required deployment ID of the deployment that holds the Elasticsearch cluster where the keystore setting will be written to`,
+			Required:    true,
+			ForceNew:    true,
+		},
+		"setting_name": {
+			Type:        schema.TypeString,
+			Description: "Required name for the keystore setting, if the setting already exists in the Elasticsearch cluster, it will be overridden",
+			Required:    true,
+			ForceNew:    true,
+		},
+		"value": {
+			Type:         schema.TypeString,
+			Description:  "Optional value of this setting. This can either be a string or a JSON object that is stored as a JSON string in the keystore",
+			Sensitive:    true,
+			Optional:     true,
+			StateFunc:    hashValue,
+			ExactlyOneOf: []string{"value", "value_base64"},
+		},
+		"value_base64": {
+			Type:         schema.TypeString,
+			Description:  "Optional base64 encoded content of a file setting, such as a service account JSON file, which is always stored as a file. The decoded content must be UTF-8 text, binary files aren't supported",
+			Sensitive:    true,
+			Optional:     true,
+			StateFunc:    hashValue,
+			ValidateFunc: validateBase64Text,
+			ExactlyOneOf: []string{"value", "value_base64"},
+		},
+		"as_file": {
+			Type:          schema.TypeBool,
+			Description:   "Optionally stores the remote keystore setting as a file. The default is false, which stores the keystore setting as string when value is a plain string",
+			Optional:      true,
+			Default:       false,
+			ConflictsWith: []string{"value_base64"},
+		},
+	}
+}
+
+// hashValue returns the SHA-256 checksum of a setting value, which is stored in
+// the state rather than the plaintext value. Since the keystore values are never
+// read back from the API, the checksum is enough to detect changes to them.
+func hashValue(v interface{}) string {
+	value, _ := v.(string)
+	return fmt.Sprintf("%x", sha256.Sum256([]byte(value)))
+}
+
+// validateBase64Text checks the value is base64 encoded UTF-8 text. The secret
+// value is sent to the API as a JSON string, which can't hold binary content.
+func validateBase64Text(i interface{}, k string) ([]string, []error) {
+	if warnings, errs := validation.StringIsBase64(i, k); len(errs) > 0 {
+		return warnings, errs
+	}
+
+	content, _ := base64.StdEncoding.DecodeString(i.(string))
+	if !utf8.Valid(content) {
+		return nil, []error{fmt.Errorf(
+			"expected %q to be base64 encoded UTF-8 text, binary content isn't supported", k,
+		)}
+	}
+
+	return nil, nil
+}
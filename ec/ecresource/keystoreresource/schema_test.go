@@ -0,0 +1,54 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package keystoreresource
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_validateBase64Text(t *testing.T) {
+	tests := []struct {
+		name string
+		v    interface{}
+		errs []error
+	}{
+		{
+			name: "accepts base64 encoded text",
+			v:    "eyJ0eXBlIjogInNlcnZpY2VfYWNjb3VudCJ9",
+		},
+		{
+			name: "rejects content which isn't base64 encoded",
+			v:    "not base64!",
+			errs: []error{errors.New(`expected "value_base64" to be a base64 string, got not base64!`)},
+		},
+		{
+			name: "rejects base64 encoded binary content",
+			v:    "//79",
+			errs: []error{errors.New(`expected "value_base64" to be base64 encoded UTF-8 text, binary content isn't supported`)},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, errs := validateBase64Text(tt.v, "value_base64")
+			assert.Equal(t, tt.errs, errs)
+		})
+	}
+}
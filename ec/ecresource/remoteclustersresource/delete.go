@@ -0,0 +1,52 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package remoteclustersresource
+
+import (
+	"context"
+
+	"github.com/elastic/cloud-sdk-go/pkg/api"
+	"github.com/elastic/cloud-sdk-go/pkg/client/deployments"
+	"github.com/elastic/cloud-sdk-go/pkg/models"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+
+	"github.com/elastic/terraform-provider-ec/ec/util"
+)
+
+// delete removes all the deployment's remote clusters.
+func delete(_ context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	var client = meta.(*util.ProviderMeta).API
+	defer util.LockDeployment(d.Id())()
+
+	if _, err := client.V1API.Deployments.SetDeploymentEsResourceRemoteClusters(
+		deployments.NewSetDeploymentEsResourceRemoteClustersParams().
+			WithDeploymentID(d.Id()).
+			WithRefID(d.Get("ref_id").(string)).
+			WithBody(&models.RemoteResources{Resources: []*models.RemoteResourceRef{}}),
+		client.AuthWriter,
+	); err != nil {
+		// The remote clusters are already gone with the deployment.
+		if _, ok := err.(*deployments.SetDeploymentEsResourceRemoteClustersNotFound); !ok {
+			return util.ErrorDiagnostics(api.UnwrapError(err))
+		}
+	}
+
+	d.SetId("")
+	return nil
+}
@@ -0,0 +1,39 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package remoteclustersresource
+
+import (
+	"github.com/elastic/cloud-sdk-go/pkg/client/deployments"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+
+	"github.com/elastic/terraform-provider-ec/ec/ecresource/deploymentresource/elasticsearchstate"
+)
+
+// expand returns the parameters which replace the Elasticsearch resource's
+// remote clusters with the configured ones.
+func expand(d *schema.ResourceData) *deployments.SetDeploymentEsResourceRemoteClustersParams {
+	var raw []interface{}
+	if set, ok := d.Get("remote_cluster").(*schema.Set); ok {
+		raw = set.List()
+	}
+
+	return deployments.NewSetDeploymentEsResourceRemoteClustersParams().
+		WithDeploymentID(d.Get("deployment_id").(string)).
+		WithRefID(d.Get("ref_id").(string)).
+		WithBody(elasticsearchstate.ExpandRemoteClusters(raw))
+}
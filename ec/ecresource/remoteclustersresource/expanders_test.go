@@ -0,0 +1,78 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package remoteclustersresource
+
+import (
+	"testing"
+
+	"github.com/elastic/cloud-sdk-go/pkg/api/mock"
+	"github.com/elastic/cloud-sdk-go/pkg/client/deployments"
+	"github.com/elastic/cloud-sdk-go/pkg/models"
+	"github.com/elastic/cloud-sdk-go/pkg/util/ec"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_expand(t *testing.T) {
+	rd := newResourceData(t, resDataParams{
+		Resources: newSampleRemoteClusters(),
+		ID:        mock.ValidClusterID,
+	})
+	emptyRd := newResourceData(t, resDataParams{
+		Resources: map[string]interface{}{"deployment_id": mock.ValidClusterID},
+		ID:        mock.ValidClusterID,
+	})
+	type args struct {
+		d *schema.ResourceData
+	}
+	tests := []struct {
+		name string
+		args args
+		want *deployments.SetDeploymentEsResourceRemoteClustersParams
+	}{
+		{
+			name: "expands the resource data",
+			args: args{d: rd},
+			want: deployments.NewSetDeploymentEsResourceRemoteClustersParams().
+				WithDeploymentID(mock.ValidClusterID).
+				WithRefID("main-elasticsearch").
+				WithBody(&models.RemoteResources{Resources: []*models.RemoteResourceRef{{
+					DeploymentID:       ec.String(mockRemoteDeploymentID),
+					ElasticsearchRefID: ec.String("main-elasticsearch"),
+					Alias:              ec.String("my-remote"),
+					SkipUnavailable:    ec.Bool(false),
+				}}}),
+		},
+		{
+			name: "expands the resource data without remote clusters",
+			args: args{d: emptyRd},
+			want: deployments.NewSetDeploymentEsResourceRemoteClustersParams().
+				WithDeploymentID(mock.ValidClusterID).
+				WithRefID("main-elasticsearch").
+				WithBody(&models.RemoteResources{Resources: []*models.RemoteResourceRef{}}),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := expand(tt.args.d)
+			assert.Equal(t, tt.want.DeploymentID, got.DeploymentID)
+			assert.Equal(t, tt.want.RefID, got.RefID)
+			assert.Equal(t, tt.want.Body, got.Body)
+		})
+	}
+}
@@ -0,0 +1,77 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package remoteclustersresource
+
+import (
+	"testing"
+
+	"github.com/elastic/cloud-sdk-go/pkg/api/mock"
+	"github.com/elastic/cloud-sdk-go/pkg/models"
+	"github.com/elastic/cloud-sdk-go/pkg/util/ec"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_flatten(t *testing.T) {
+	tests := []struct {
+		name string
+		res  *models.RemoteResources
+		want []interface{}
+	}{
+		{
+			name: "empty response keeps the state",
+			want: []interface{}{map[string]interface{}{
+				"deployment_id":    mockRemoteDeploymentID,
+				"alias":            "my-remote",
+				"ref_id":           "main-elasticsearch",
+				"skip_unavailable": false,
+			}},
+		},
+		{
+			name: "flattens the response",
+			res: &models.RemoteResources{Resources: []*models.RemoteResourceRef{{
+				DeploymentID:       ec.String(mockRemoteDeploymentID),
+				ElasticsearchRefID: ec.String("main-elasticsearch"),
+				Alias:              ec.String("other-alias"),
+				SkipUnavailable:    ec.Bool(true),
+			}}},
+			want: []interface{}{map[string]interface{}{
+				"deployment_id":    mockRemoteDeploymentID,
+				"alias":            "other-alias",
+				"ref_id":           "main-elasticsearch",
+				"skip_unavailable": true,
+			}},
+		},
+		{
+			name: "flattens a response without remote clusters",
+			res:  &models.RemoteResources{Resources: []*models.RemoteResourceRef{}},
+			want: []interface{}{},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			d := newResourceData(t, resDataParams{
+				Resources: newSampleRemoteClusters(),
+				ID:        mock.ValidClusterID,
+			})
+			err := flatten(tt.res, d)
+			assert.NoError(t, err)
+			assert.Equal(t, tt.want, d.Get("remote_cluster").(*schema.Set).List())
+		})
+	}
+}
@@ -0,0 +1,70 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package remoteclustersresource
+
+import (
+	"context"
+
+	"github.com/elastic/cloud-sdk-go/pkg/api"
+	"github.com/elastic/cloud-sdk-go/pkg/client/deployments"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+
+	"github.com/elastic/terraform-provider-ec/ec/util"
+)
+
+// read queries the deployment's remote clusters and updates the local state.
+func read(_ context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	var client = meta.(*util.ProviderMeta).API
+
+	// The ID is the deployment ID, which allows the resource to be imported
+	// with the default ref_id.
+	if err := d.Set("deployment_id", d.Id()); err != nil {
+		return diag.FromErr(err)
+	}
+
+	refID := d.Get("ref_id").(string)
+	if refID == "" {
+		refID = defaultRefID
+		if err := d.Set("ref_id", refID); err != nil {
+			return diag.FromErr(err)
+		}
+	}
+
+	res, err := client.V1API.Deployments.GetDeploymentEsResourceRemoteClusters(
+		deployments.NewGetDeploymentEsResourceRemoteClustersParams().
+			WithDeploymentID(d.Id()).
+			WithRefID(refID),
+		client.AuthWriter,
+	)
+	if err != nil {
+		// The deployment was deleted outside of terraform, so the resource is
+		// removed from the state.
+		if _, ok := err.(*deployments.GetDeploymentEsResourceRemoteClustersNotFound); ok {
+			d.SetId("")
+			return nil
+		}
+		return util.ErrorDiagnostics(api.UnwrapError(err))
+	}
+
+	if err := flatten(res.Payload, d); err != nil {
+		return diag.FromErr(err)
+	}
+
+	return nil
+}
@@ -0,0 +1,82 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package remoteclustersresource
+
+import (
+	"regexp"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+// defaultRefID is the ref_id of the deployments' Elasticsearch resource.
+const defaultRefID = "main-elasticsearch"
+
+// aliasRegex matches the valid remote cluster aliases.
+var aliasRegex = regexp.MustCompile(`^[a-zA-Z0-9_-]+$`)
+
+// newSchema returns the schema for an "ec_deployment_remote_clusters" resource.
+func newSchema() map[string]*schema.Schema {
+	return map[string]*schema.Schema{
+		"deployment_id": {
+			Type:        schema.TypeString,
+			Description: `Required ID of the deployment whose remote clusters are managed`,
+			Required:    true,
+			ForceNew:    true,
+		},
+		"ref_id": {
+			Type:        schema.TypeString,
+			Description: `Optional ref_id of the deployment's Elasticsearch resource`,
+			Default:     defaultRefID,
+			Optional:    true,
+			ForceNew:    true,
+		},
+		"remote_cluster": {
+			Type:        schema.TypeSet,
+			Description: `Optional remote clusters, which replace all the Elasticsearch resource's remote clusters`,
+			Optional:    true,
+			Elem: &schema.Resource{
+				Schema: map[string]*schema.Schema{
+					"deployment_id": {
+						Type:        schema.TypeString,
+						Description: `Required ID of the remote deployment`,
+						Required:    true,
+					},
+					"alias": {
+						Type:         schema.TypeString,
+						Description:  `Required alias for the remote cluster, which must only contain letters, digits, dashes and underscores`,
+						Required:     true,
+						ValidateFunc: validation.StringMatch(aliasRegex, "must only contain letters, digits, dashes and underscores"),
+					},
+					"ref_id": {
+						Type:        schema.TypeString,
+						Description: `Optional ref_id of the remote deployment's Elasticsearch resource`,
+						Default:     defaultRefID,
+						Optional:    true,
+					},
+					"skip_unavailable": {
+						Type:        schema.TypeBool,
+						Description: `Optionally skips the remote cluster during search when it's disconnected`,
+						Default:     false,
+						Optional:    true,
+					},
+				},
+			},
+		},
+	}
+}
@@ -22,20 +22,25 @@ import (
 	"strconv"
 	"strings"
 
-	"github.com/elastic/cloud-sdk-go/pkg/api"
 	"github.com/elastic/cloud-sdk-go/pkg/api/deploymentapi/trafficfilterapi"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+
+	"github.com/elastic/terraform-provider-ec/ec/util"
 )
 
 // create will create a new deployment traffic filter ruleset association.
 func create(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	client := meta.(*api.API)
+	providerMeta := meta.(*util.ProviderMeta)
+	client := providerMeta.API
 	params := expand(d)
 	params.API = client
+	defer util.LockDeployment(params.EntityID)()
 
-	if err := trafficfilterapi.CreateAssociation(params); err != nil {
-		return diag.FromErr(err)
+	err := trafficfilterapi.CreateAssociation(params)
+	providerMeta.ForgetTrafficFilter(params.ID)
+	if err != nil {
+		return util.ErrorDiagnostics(err)
 	}
 
 	d.SetId(hashID(params.EntityID, params.ID))
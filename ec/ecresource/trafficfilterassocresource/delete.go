@@ -20,19 +20,23 @@ package trafficfilterassocresource
 import (
 	"context"
 
-	"github.com/elastic/cloud-sdk-go/pkg/api"
 	"github.com/elastic/cloud-sdk-go/pkg/api/deploymentapi/trafficfilterapi"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+
+	"github.com/elastic/terraform-provider-ec/ec/util"
 )
 
 // delete will delete an existing deployment traffic filter ruleset association.
 func delete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	var client = meta.(*api.API)
+	var providerMeta = meta.(*util.ProviderMeta)
+	var client = providerMeta.API
 
 	params := expand(d)
 	params.API = client
+	defer util.LockDeployment(params.EntityID)()
 
+	defer providerMeta.ForgetTrafficFilter(params.ID)
 	if err := trafficfilterapi.DeleteAssociation(trafficfilterapi.DeleteAssociationParams(params)); err != nil {
 		return diag.FromErr(err)
 	}
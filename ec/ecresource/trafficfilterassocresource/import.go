@@ -0,0 +1,46 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package trafficfilterassocresource
+
+import (
+	"context"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+
+	"github.com/elastic/terraform-provider-ec/ec/util"
+)
+
+// importFunc imports an association from a "<deployment_id>/<traffic_filter_id>"
+// composite ID.
+func importFunc(_ context.Context, d *schema.ResourceData, _ interface{}) ([]*schema.ResourceData, error) {
+	parts, err := util.ParseCompositeID(d.Id(), "deployment_id", "traffic_filter_id")
+	if err != nil {
+		return nil, err
+	}
+
+	if err := d.Set("deployment_id", parts[0]); err != nil {
+		return nil, err
+	}
+
+	if err := d.Set("traffic_filter_id", parts[1]); err != nil {
+		return nil, err
+	}
+
+	d.SetId(hashID(parts[0], parts[1]))
+	return []*schema.ResourceData{d}, nil
+}
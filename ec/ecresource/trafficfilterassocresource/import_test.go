@@ -0,0 +1,66 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package trafficfilterassocresource
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/elastic/cloud-sdk-go/pkg/api/mock"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_importFunc(t *testing.T) {
+	tests := []struct {
+		name string
+		id   string
+		want map[string]string
+		err  error
+	}{
+		{
+			name: "imports a composite id",
+			id:   mock.ValidClusterID + "/" + mockTrafficFilterID,
+			want: map[string]string{
+				"id":                hashID(mock.ValidClusterID, mockTrafficFilterID),
+				"deployment_id":     mock.ValidClusterID,
+				"traffic_filter_id": mockTrafficFilterID,
+			},
+		},
+		{
+			name: "fails on an invalid id",
+			id:   mock.ValidClusterID,
+			err: errors.New(`invalid import id "` + mock.ValidClusterID +
+				`": expected format "<deployment_id>/<traffic_filter_id>"`),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			d := newResourceData(t, resDataParams{ID: tt.id})
+			got, err := importFunc(context.Background(), d, nil)
+			if tt.err != nil {
+				assert.EqualError(t, err, tt.err.Error())
+				return
+			}
+
+			assert.NoError(t, err)
+			assert.Len(t, got, 1)
+			assert.Equal(t, tt.want, got[0].State().Attributes)
+		})
+	}
+}
@@ -20,21 +20,17 @@ package trafficfilterassocresource
 import (
 	"context"
 
-	"github.com/elastic/cloud-sdk-go/pkg/api"
-	"github.com/elastic/cloud-sdk-go/pkg/api/deploymentapi/trafficfilterapi"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+
+	"github.com/elastic/terraform-provider-ec/ec/util"
 )
 
 // read queries the remote deployment traffic filter ruleset association and
 // updates the local state.
 func read(_ context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	var client = meta.(*api.API)
-	res, err := trafficfilterapi.Get(trafficfilterapi.GetParams{
-		API:                 client,
-		ID:                  d.Get("traffic_filter_id").(string),
-		IncludeAssociations: true,
-	})
+	var providerMeta = meta.(*util.ProviderMeta)
+	res, err := providerMeta.GetTrafficFilter(d.Get("traffic_filter_id").(string))
 	if err != nil {
 		return diag.FromErr(err)
 	}
@@ -33,6 +33,9 @@ func Resource() *schema.Resource {
 		ReadContext:   read,
 		DeleteContext: delete,
 
+		Importer: &schema.ResourceImporter{
+			StateContext: importFunc,
+		},
 		Timeouts: &schema.ResourceTimeout{
 			Default: schema.DefaultTimeout(5 * time.Minute),
 		},
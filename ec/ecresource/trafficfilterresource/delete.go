@@ -24,12 +24,16 @@ import (
 	"github.com/elastic/cloud-sdk-go/pkg/api/deploymentapi/trafficfilterapi"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+
+	"github.com/elastic/terraform-provider-ec/ec/util"
 )
 
 // Delete will delete an existing deployment traffic filter ruleset
 func delete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	var client = meta.(*api.API)
+	var providerMeta = meta.(*util.ProviderMeta)
+	var client = providerMeta.API
 
+	defer providerMeta.ForgetTrafficFilter(d.Id())
 	res, err := trafficfilterapi.Get(trafficfilterapi.GetParams{
 		API: client, ID: d.Id(), IncludeAssociations: true,
 	})
@@ -20,20 +20,18 @@ package trafficfilterresource
 import (
 	"context"
 
-	"github.com/elastic/cloud-sdk-go/pkg/api"
-	"github.com/elastic/cloud-sdk-go/pkg/api/deploymentapi/trafficfilterapi"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+
+	"github.com/elastic/terraform-provider-ec/ec/util"
 )
 
 // Read queries the remote deployment traffic filter ruleset state and update
 // the local state.
 func read(_ context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	var client = meta.(*api.API)
+	var providerMeta = meta.(*util.ProviderMeta)
 
-	res, err := trafficfilterapi.Get(trafficfilterapi.GetParams{
-		API: client, ID: d.Id(),
-	})
+	res, err := providerMeta.GetTrafficFilter(d.Id())
 	if err != nil {
 		return diag.FromErr(err)
 	}
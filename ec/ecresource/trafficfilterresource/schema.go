@@ -19,8 +19,15 @@ package trafficfilterresource
 
 import (
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 )
 
+// rulesetTypes are the supported traffic filter ruleset types, which define
+// the kind of source the ruleset's rules allow traffic from.
+var rulesetTypes = []string{
+	"ip", "vpce", "gcp_private_service_connect_endpoint",
+}
+
 // NewSchema returns the schema for an "ec_deployment_traffic_filter" resource.
 func NewSchema() map[string]*schema.Schema {
 	return map[string]*schema.Schema{
@@ -30,9 +37,10 @@ func NewSchema() map[string]*schema.Schema {
 			Required:    true,
 		},
 		"type": {
-			Type:        schema.TypeString,
-			Description: `Required type of the ruleset ("ip" or "vpce")`,
-			Required:    true,
+			Type:         schema.TypeString,
+			Description:  `Required type of the ruleset ("ip", "vpce" or "gcp_private_service_connect_endpoint")`,
+			Required:     true,
+			ValidateFunc: validation.StringInSlice(rulesetTypes, false),
 		},
 		"region": {
 			Type:        schema.TypeString,
@@ -48,7 +56,7 @@ func NewSchema() map[string]*schema.Schema {
 				Schema: map[string]*schema.Schema{
 					"source": {
 						Type:        schema.TypeString,
-						Description: "Required traffic filter source: IP address, CIDR mask, or VPC endpoint ID",
+						Description: "Required traffic filter source: IP address, CIDR mask, VPC endpoint ID or GCP Private Service Connect connection ID",
 						Required:    true,
 					},
 
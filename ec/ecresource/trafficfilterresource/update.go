@@ -20,20 +20,23 @@ package trafficfilterresource
 import (
 	"context"
 
-	"github.com/elastic/cloud-sdk-go/pkg/api"
 	"github.com/elastic/cloud-sdk-go/pkg/api/deploymentapi/trafficfilterapi"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+
+	"github.com/elastic/terraform-provider-ec/ec/util"
 )
 
 // Update will update an existing deployment traffic filter ruleset
 func update(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	var client = meta.(*api.API)
+	var providerMeta = meta.(*util.ProviderMeta)
+	var client = providerMeta.API
 
 	_, err := trafficfilterapi.Update(trafficfilterapi.UpdateParams{
 		API: client, ID: d.Id(),
 		Req: expandModel(d),
 	})
+	providerMeta.ForgetTrafficFilter(d.Id())
 	if err != nil {
 		return diag.FromErr(err)
 	}
@@ -0,0 +1,56 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package ectransport
+
+import "net/http"
+
+// HeadersTransport is an http.RoundTripper which adds a set of extra headers
+// to every request, such as the tokens required by a corporate gateway or the
+// trace propagation headers.
+type HeadersTransport struct {
+	rt      http.RoundTripper
+	headers http.Header
+}
+
+// NewHeadersTransport wraps the specified http.RoundTripper adding the headers
+// to every request.
+func NewHeadersTransport(rt http.RoundTripper, headers map[string]string) *HeadersTransport {
+	var h = make(http.Header, len(headers))
+	for k, v := range headers {
+		h.Set(k, v)
+	}
+	return &HeadersTransport{rt: rt, headers: h}
+}
+
+// RoundTrip performs the request with the extra headers. The headers which
+// are already set on the request, such as the authorization, aren't replaced.
+func (t *HeadersTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	if len(t.headers) == 0 {
+		return t.rt.RoundTrip(req)
+	}
+
+	// The request must not be modified by a RoundTripper, so it's cloned.
+	var r = req.Clone(req.Context())
+	for k, v := range t.headers {
+		if r.Header.Get(k) == "" {
+			r.Header[k] = v
+		}
+	}
+
+	return t.rt.RoundTrip(r)
+}
@@ -0,0 +1,53 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package ectransport
+
+import (
+	"net/http"
+	"net/url"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestHeadersTransport_RoundTrip(t *testing.T) {
+	var got http.Header
+	rt := NewHeadersTransport(roundTripperFunc(func(req *http.Request) (*http.Response, error) {
+		got = req.Header
+		return &http.Response{StatusCode: 200}, nil
+	}), map[string]string{
+		"x-gateway-token": "some-token",
+		"Authorization":   "some-other-auth",
+	})
+
+	req := &http.Request{
+		Method: "GET",
+		URL:    &url.URL{Path: "/api/v1/deployments"},
+		Header: http.Header{"Authorization": []string{"ApiKey some-key"}},
+	}
+	_, err := rt.RoundTrip(req)
+	assert.NoError(t, err)
+
+	assert.Equal(t, http.Header{
+		"Authorization":   []string{"ApiKey some-key"},
+		"X-Gateway-Token": []string{"some-token"},
+	}, got)
+
+	// The original request isn't modified.
+	assert.Equal(t, http.Header{"Authorization": []string{"ApiKey some-key"}}, req.Header)
+}
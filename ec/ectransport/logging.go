@@ -0,0 +1,86 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package ectransport
+
+import (
+	"encoding/json"
+	"log"
+	"net/http"
+	"time"
+)
+
+var requestIDHeaders = []string{"X-Cloud-Request-Id", "X-Request-Id"}
+
+// LoggingTransport is an http.RoundTripper which logs a single structured
+// JSON line for each API call at the DEBUG level, which is only displayed by
+// Terraform when TF_LOG=DEBUG (or a more verbose level) is set.
+type LoggingTransport struct {
+	rt   http.RoundTripper
+	logf func(format string, v ...interface{})
+}
+
+type apiCallLog struct {
+	Method    string `json:"method"`
+	Path      string `json:"path"`
+	Status    int    `json:"status"`
+	LatencyMS int64  `json:"latency_ms"`
+	RequestID string `json:"request_id,omitempty"`
+	Error     string `json:"error,omitempty"`
+}
+
+// NewLoggingTransport wraps the specified http.RoundTripper.
+func NewLoggingTransport(rt http.RoundTripper) *LoggingTransport {
+	return &LoggingTransport{rt: rt, logf: log.Printf}
+}
+
+// RoundTrip performs the request and logs the outcome.
+func (t *LoggingTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	start := time.Now()
+	res, err := t.rt.RoundTrip(req)
+
+	var entry = apiCallLog{
+		Method:    req.Method,
+		Path:      req.URL.Path,
+		LatencyMS: time.Since(start).Milliseconds(),
+	}
+
+	if res != nil {
+		entry.Status = res.StatusCode
+		entry.RequestID = requestID(res.Header)
+	}
+
+	if err != nil {
+		entry.Error = err.Error()
+	}
+
+	if b, e := json.Marshal(entry); e == nil {
+		t.logf("[DEBUG] ec api call: %s", b)
+	}
+
+	return res, err
+}
+
+func requestID(h http.Header) string {
+	for _, key := range requestIDHeaders {
+		if id := h.Get(key); id != "" {
+			return id
+		}
+	}
+
+	return ""
+}
@@ -0,0 +1,74 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package ectransport
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type roundTripperFunc func(*http.Request) (*http.Response, error)
+
+func (f roundTripperFunc) RoundTrip(req *http.Request) (*http.Response, error) {
+	return f(req)
+}
+
+func TestLoggingTransport_RoundTrip(t *testing.T) {
+	tests := []struct {
+		name string
+		rt   roundTripperFunc
+		want string
+	}{
+		{
+			name: "logs a successful API call with its request id",
+			rt: func(*http.Request) (*http.Response, error) {
+				return &http.Response{StatusCode: 200, Header: http.Header{
+					"X-Cloud-Request-Id": []string{"some-request-id"},
+				}}, nil
+			},
+			want: `[DEBUG] ec api call: {"method":"GET","path":"/api/v1/deployments","status":200,"latency_ms":0,"request_id":"some-request-id"}`,
+		},
+		{
+			name: "logs a failed API call",
+			rt: func(*http.Request) (*http.Response, error) {
+				return nil, errors.New("connection refused")
+			},
+			want: `[DEBUG] ec api call: {"method":"GET","path":"/api/v1/deployments","status":0,"latency_ms":0,"error":"connection refused"}`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var got string
+			transport := NewLoggingTransport(tt.rt)
+			transport.logf = func(format string, v ...interface{}) {
+				got = fmt.Sprintf(format, v...)
+			}
+
+			_, _ = transport.RoundTrip(&http.Request{
+				Method: http.MethodGet,
+				URL:    &url.URL{Path: "/api/v1/deployments"},
+			})
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
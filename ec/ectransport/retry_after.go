@@ -0,0 +1,177 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package ectransport
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"log"
+	"net/http"
+	"strconv"
+	"time"
+)
+
+// RetryAfterError is returned by the RetryAfterTransport when the API is
+// temporarily unavailable, i.e. during a maintenance window.
+type RetryAfterError struct {
+	// Wait is the time the API asked to wait before retrying the request.
+	Wait time.Duration
+}
+
+func (e *RetryAfterError) Error() string {
+	return fmt.Sprintf("the API is temporarily unavailable, retry after %s", e.Wait)
+}
+
+// RetryAfter returns the time to wait before retrying a call which failed
+// because the API was temporarily unavailable. The returned bool is false
+// when the error isn't caused by a RetryAfterError.
+func RetryAfter(err error) (time.Duration, bool) {
+	var retryErr *RetryAfterError
+	if errors.As(err, &retryErr) {
+		return retryErr.Wait, true
+	}
+	return 0, false
+}
+
+const (
+	// maxRetryAfterWait is the longest Retry-After wait performed by the
+	// transport. Longer waits are returned as a RetryAfterError, so they can be
+	// retried by the callers within the resource operation timeout.
+	maxRetryAfterWait = 30 * time.Second
+
+	// maxRetryAfterAttempts is the number of times a request is sent before
+	// the RetryAfterError is returned.
+	maxRetryAfterAttempts = 3
+)
+
+// RetryAfterTransport is an http.RoundTripper which retries the requests
+// receiving a 503 response with a Retry-After header, i.e. during a
+// maintenance window. The request is only retried when the wait is short and
+// ends before the request context deadline, otherwise a RetryAfterError is
+// returned.
+type RetryAfterTransport struct {
+	rt  http.RoundTripper
+	now func() time.Time
+}
+
+// NewRetryAfterTransport wraps the specified http.RoundTripper.
+func NewRetryAfterTransport(rt http.RoundTripper) *RetryAfterTransport {
+	return &RetryAfterTransport{rt: rt, now: time.Now}
+}
+
+// RoundTrip performs the request, retrying it when the API responds with a
+// 503 status code and a valid Retry-After header. A RetryAfterError is
+// returned when the request can't be retried.
+func (t *RetryAfterTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	for attempt := 1; ; attempt++ {
+		res, err := t.rt.RoundTrip(req)
+		if err != nil || res.StatusCode != http.StatusServiceUnavailable {
+			return res, err
+		}
+
+		wait, ok := parseRetryAfter(res.Header.Get("Retry-After"), t.now())
+		if !ok {
+			return res, err
+		}
+
+		if res.Body != nil {
+			_, _ = io.Copy(ioutil.Discard, res.Body)
+			res.Body.Close()
+		}
+
+		if attempt >= maxRetryAfterAttempts || !t.canRetry(req, wait) {
+			return nil, &RetryAfterError{Wait: wait}
+		}
+
+		log.Printf("[WARN] the Elastic Cloud API is temporarily unavailable, most likely due to maintenance, retrying %s %s in %s (attempt %d of %d)",
+			req.Method, req.URL.Path, wait, attempt+1, maxRetryAfterAttempts,
+		)
+		if req, err = waitAndRewind(req, wait); err != nil {
+			return nil, err
+		}
+	}
+}
+
+// canRetry returns true when the request body can be sent again and the wait
+// ends before the request context deadline.
+func (t *RetryAfterTransport) canRetry(req *http.Request, wait time.Duration) bool {
+	if wait > maxRetryAfterWait {
+		return false
+	}
+
+	if req.Body != nil && req.Body != http.NoBody && req.GetBody == nil {
+		return false
+	}
+
+	if deadline, ok := req.Context().Deadline(); ok && t.now().Add(wait).After(deadline) {
+		return false
+	}
+
+	return true
+}
+
+// waitAndRewind waits for the specified duration or until the request context
+// is done, returning a copy of the request with a new body.
+func waitAndRewind(req *http.Request, wait time.Duration) (*http.Request, error) {
+	var timer = time.NewTimer(wait)
+	defer timer.Stop()
+
+	select {
+	case <-req.Context().Done():
+		return nil, req.Context().Err()
+	case <-timer.C:
+	}
+
+	next := req.Clone(req.Context())
+	if req.GetBody != nil {
+		body, err := req.GetBody()
+		if err != nil {
+			return nil, err
+		}
+		next.Body = body
+	}
+
+	return next, nil
+}
+
+// parseRetryAfter parses the Retry-After header value, which is either a
+// number of seconds or an HTTP date.
+func parseRetryAfter(v string, now time.Time) (time.Duration, bool) {
+	if v == "" {
+		return 0, false
+	}
+
+	if secs, err := strconv.Atoi(v); err == nil {
+		if secs < 0 {
+			return 0, false
+		}
+		return time.Duration(secs) * time.Second, true
+	}
+
+	date, err := http.ParseTime(v)
+	if err != nil {
+		return 0, false
+	}
+
+	if wait := date.Sub(now); wait > 0 {
+		return wait, true
+	}
+	return 0, true
+}
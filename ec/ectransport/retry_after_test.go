@@ -0,0 +1,176 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package ectransport
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRetryAfterTransport_RoundTrip(t *testing.T) {
+	var now = time.Date(2020, 9, 1, 0, 0, 0, 0, time.UTC)
+	newResponse := func(code int, retryAfter string) *http.Response {
+		var h = http.Header{}
+		if retryAfter != "" {
+			h.Set("Retry-After", retryAfter)
+		}
+		return &http.Response{
+			StatusCode: code, Header: h,
+			Body: ioutil.NopCloser(strings.NewReader("{}")),
+		}
+	}
+
+	tests := []struct {
+		name       string
+		res        *http.Response
+		wantStatus int
+		err        error
+	}{
+		{
+			name:       "returns a successful response",
+			res:        newResponse(200, ""),
+			wantStatus: 200,
+		},
+		{
+			name:       "returns a 503 response without a Retry-After header",
+			res:        newResponse(503, ""),
+			wantStatus: 503,
+		},
+		{
+			name:       "returns a 503 response with an invalid Retry-After header",
+			res:        newResponse(503, "soon"),
+			wantStatus: 503,
+		},
+		{
+			name: "returns an error when the Retry-After seconds are too long",
+			res:  newResponse(503, "120"),
+			err:  &RetryAfterError{Wait: 2 * time.Minute},
+		},
+		{
+			name: "returns an error when the time until the Retry-After date is too long",
+			res:  newResponse(503, now.Add(time.Minute).Format(http.TimeFormat)),
+			err:  &RetryAfterError{Wait: time.Minute},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rt := NewRetryAfterTransport(roundTripperFunc(func(*http.Request) (*http.Response, error) {
+				return tt.res, nil
+			}))
+			rt.now = func() time.Time { return now }
+
+			req := &http.Request{Method: "GET", URL: &url.URL{Path: "/api/v1/deployments"}}
+			res, err := rt.RoundTrip(req)
+			assert.Equal(t, tt.err, err)
+			if tt.err == nil {
+				assert.Equal(t, tt.wantStatus, res.StatusCode)
+			}
+		})
+	}
+}
+
+func TestRetryAfterTransport_RoundTripRetries(t *testing.T) {
+	newResponse := func(code int, retryAfter string) *http.Response {
+		return &http.Response{
+			StatusCode: code, Header: http.Header{"Retry-After": []string{retryAfter}},
+			Body: ioutil.NopCloser(strings.NewReader("{}")),
+		}
+	}
+	expiredCtx, cancel := context.WithDeadline(context.Background(), time.Now().Add(time.Second))
+	defer cancel()
+
+	tests := []struct {
+		name         string
+		ctx          context.Context
+		responses    []*http.Response
+		wantStatus   int
+		wantAttempts int
+		err          error
+	}{
+		{
+			name:         "retries the request after the Retry-After wait",
+			ctx:          context.Background(),
+			responses:    []*http.Response{newResponse(503, "0"), newResponse(200, "")},
+			wantStatus:   200,
+			wantAttempts: 2,
+		},
+		{
+			name: "returns an error after the maximum attempts",
+			ctx:  context.Background(),
+			responses: []*http.Response{
+				newResponse(503, "0"), newResponse(503, "0"), newResponse(503, "0"),
+			},
+			wantAttempts: 3,
+			err:          &RetryAfterError{},
+		},
+		{
+			name:         "returns an error when the wait ends after the context deadline",
+			ctx:          expiredCtx,
+			responses:    []*http.Response{newResponse(503, "10")},
+			wantAttempts: 1,
+			err:          &RetryAfterError{Wait: 10 * time.Second},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var attempts int
+			rt := NewRetryAfterTransport(roundTripperFunc(func(req *http.Request) (*http.Response, error) {
+				body, err := ioutil.ReadAll(req.Body)
+				assert.NoError(t, err)
+				assert.Equal(t, `{"name":"some"}`, string(body))
+
+				attempts++
+				return tt.responses[attempts-1], nil
+			}))
+
+			req, err := http.NewRequestWithContext(tt.ctx, "POST", "https://api/api/v1/deployments",
+				strings.NewReader(`{"name":"some"}`),
+			)
+			if !assert.NoError(t, err) {
+				return
+			}
+
+			res, err := rt.RoundTrip(req)
+			assert.Equal(t, tt.err, err)
+			assert.Equal(t, tt.wantAttempts, attempts)
+			if tt.err == nil {
+				assert.Equal(t, tt.wantStatus, res.StatusCode)
+			}
+		})
+	}
+}
+
+func TestRetryAfter(t *testing.T) {
+	wait, ok := RetryAfter(&url.Error{
+		Op: "Get", URL: "/api/v1/deployments", Err: &RetryAfterError{Wait: time.Minute},
+	})
+	assert.True(t, ok)
+	assert.Equal(t, time.Minute, wait)
+
+	_, ok = RetryAfter(fmt.Errorf("wrapped: %w", errors.New("some error")))
+	assert.False(t, ok)
+}
@@ -0,0 +1,98 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package ectransport
+
+import (
+	"math"
+	"net/http"
+	"sync"
+	"time"
+)
+
+// ThrottleTransport is an http.RoundTripper which limits the rate of the
+// outgoing requests using a token bucket. Since the transport is shared by
+// all the resources and data sources, requests are throttled provider-wide.
+type ThrottleTransport struct {
+	rt     http.RoundTripper
+	bucket *tokenBucket
+}
+
+// NewThrottleTransport wraps the specified http.RoundTripper allowing up to
+// rate requests per second with bursts of up to burst requests.
+func NewThrottleTransport(rt http.RoundTripper, rate float64, burst int) *ThrottleTransport {
+	return &ThrottleTransport{rt: rt, bucket: newTokenBucket(rate, burst)}
+}
+
+// RoundTrip waits until the request is allowed by the token bucket or the
+// request context is done, then performs the request.
+func (t *ThrottleTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	if wait := t.bucket.reserve(); wait > 0 {
+		timer := time.NewTimer(wait)
+		defer timer.Stop()
+
+		select {
+		case <-req.Context().Done():
+			return nil, req.Context().Err()
+		case <-timer.C:
+		}
+	}
+
+	return t.rt.RoundTrip(req)
+}
+
+type tokenBucket struct {
+	mu     sync.Mutex
+	rate   float64
+	burst  float64
+	tokens float64
+	last   time.Time
+	now    func() time.Time
+}
+
+func newTokenBucket(rate float64, burst int) *tokenBucket {
+	if burst < 1 {
+		burst = 1
+	}
+
+	return &tokenBucket{
+		rate:   rate,
+		burst:  float64(burst),
+		tokens: float64(burst),
+		last:   time.Now(),
+		now:    time.Now,
+	}
+}
+
+// reserve takes a token from the bucket and returns the time the caller needs
+// to wait until the token is available. Tokens are reserved even when they're
+// not yet available, so concurrent callers are queued in order.
+func (b *tokenBucket) reserve() time.Duration {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	now := b.now()
+	b.tokens = math.Min(b.burst, b.tokens+now.Sub(b.last).Seconds()*b.rate)
+	b.last = now
+
+	b.tokens--
+	if b.tokens >= 0 {
+		return 0
+	}
+
+	return time.Duration(-b.tokens / b.rate * float64(time.Second))
+}
@@ -0,0 +1,46 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package ectransport
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_tokenBucket_reserve(t *testing.T) {
+	var now = time.Date(2020, 9, 1, 0, 0, 0, 0, time.UTC)
+	var bucket = newTokenBucket(2, 2)
+	bucket.last = now
+	bucket.now = func() time.Time { return now }
+
+	// The burst is consumed without waiting.
+	assert.Equal(t, time.Duration(0), bucket.reserve())
+	assert.Equal(t, time.Duration(0), bucket.reserve())
+
+	// Subsequent reservations are queued at the configured rate.
+	assert.Equal(t, 500*time.Millisecond, bucket.reserve())
+	assert.Equal(t, time.Second, bucket.reserve())
+
+	// Once enough time has passed, the bucket is refilled up to the burst.
+	now = now.Add(10 * time.Second)
+	assert.Equal(t, time.Duration(0), bucket.reserve())
+	assert.Equal(t, time.Duration(0), bucket.reserve())
+	assert.Equal(t, 500*time.Millisecond, bucket.reserve())
+}
@@ -25,8 +25,20 @@ import (
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 
 	"github.com/elastic/terraform-provider-ec/ec/ecdatasource/deploymentdatasource"
+	"github.com/elastic/terraform-provider-ec/ec/ecdatasource/deploymentsdatasource"
+	"github.com/elastic/terraform-provider-ec/ec/ecdatasource/deploymenttemplatedatasource"
+	"github.com/elastic/terraform-provider-ec/ec/ecdatasource/deploymenttemplatesdatasource"
+	"github.com/elastic/terraform-provider-ec/ec/ecdatasource/extensionsdatasource"
+	"github.com/elastic/terraform-provider-ec/ec/ecdatasource/instanceconfigurationsdatasource"
+	"github.com/elastic/terraform-provider-ec/ec/ecdatasource/privatelinkdatasource"
 	"github.com/elastic/terraform-provider-ec/ec/ecdatasource/stackdatasource"
+	"github.com/elastic/terraform-provider-ec/ec/ecdatasource/stackversionsdatasource"
+	"github.com/elastic/terraform-provider-ec/ec/ecdatasource/trafficfilterdatasource"
+	"github.com/elastic/terraform-provider-ec/ec/ecdatasource/trafficfiltersdatasource"
 	"github.com/elastic/terraform-provider-ec/ec/ecresource/deploymentresource"
+	"github.com/elastic/terraform-provider-ec/ec/ecresource/extensionresource"
+	"github.com/elastic/terraform-provider-ec/ec/ecresource/keystoreresource"
+	"github.com/elastic/terraform-provider-ec/ec/ecresource/remoteclustersresource"
 	"github.com/elastic/terraform-provider-ec/ec/ecresource/trafficfilterassocresource"
 	"github.com/elastic/terraform-provider-ec/ec/ecresource/trafficfilterresource"
 )
@@ -39,6 +51,22 @@ const (
 	insecureDesc = "Allow the provider to skip TLS validation on its outgoing HTTP calls."
 	timeoutDesc  = "Timeout used for individual HTTP calls. Defaults to \"1m\"."
 	verboseDesc  = "When set, a \"request.log\" file will be written with all outgoing HTTP requests. Defaults to \"false\"."
+
+	disableTelemetryDesc = "When set, the provider and SDK versions are omitted from the User-Agent header sent on all outgoing HTTP requests. Defaults to \"false\"."
+	requestsPerSecDesc   = "Maximum number of API requests per second performed by the provider across all resources. Defaults to \"0\" (unlimited)."
+	requestsBurstDesc    = "Maximum number of API requests which can be performed in a burst when \"requests_per_second\" is set. Defaults to \"1\"."
+
+	maxIdleConnsPerHostDesc = "Maximum number of idle (keep-alive) connections to keep open to the API endpoint. Defaults to \"10\"."
+	maxConnsPerHostDesc     = "Maximum number of connections to the API endpoint, including those in use. Defaults to \"0\" (unlimited)."
+	idleConnTimeoutDesc     = "Time an idle (keep-alive) connection is kept open before being closed. Defaults to \"90s\"."
+	disableHTTP2Desc        = "When set, HTTP/2 isn't negotiated with the API endpoint and HTTP/1.1 is used instead. Defaults to \"false\"."
+
+	headersDesc = "Extra HTTP headers to send on every API request, e.g. the tokens required by a corporate gateway. The headers set by the provider, such as the authorization, aren't replaced."
+
+	featuresDesc          = "Opt-in behaviors which apply to all the resources managed by the provider."
+	skipFinalSnapshotDesc = "When set, no snapshot is taken before a deployment is shut down on destroy. Defaults to \"false\"."
+	pruneOrphansDesc      = "When set, the deployment resources which aren't part of the configuration are removed on update. Defaults to \"false\"."
+	retryCapacityDesc     = "When set, the deployment create and update calls which fail because of insufficient capacity are retried until the operation times out. Defaults to \"false\"."
 )
 
 var (
@@ -122,13 +150,127 @@ func Provider() *schema.Provider {
 					[]string{"EC_VERBOSE"}, false,
 				),
 			},
+			"disable_telemetry": {
+				Description: disableTelemetryDesc,
+				Type:        schema.TypeBool,
+				Optional:    true,
+				DefaultFunc: schema.MultiEnvDefaultFunc(
+					[]string{"EC_DISABLE_TELEMETRY"}, false,
+				),
+			},
+			"requests_per_second": {
+				Description:  requestsPerSecDesc,
+				Type:         schema.TypeFloat,
+				Optional:     true,
+				ValidateFunc: validation.FloatAtLeast(0),
+				DefaultFunc: schema.MultiEnvDefaultFunc(
+					[]string{"EC_REQUESTS_PER_SECOND"}, 0.0,
+				),
+			},
+			"requests_burst": {
+				Description:  requestsBurstDesc,
+				Type:         schema.TypeInt,
+				Optional:     true,
+				ValidateFunc: validation.IntAtLeast(1),
+				DefaultFunc: schema.MultiEnvDefaultFunc(
+					[]string{"EC_REQUESTS_BURST"}, 1,
+				),
+			},
+			"max_idle_conns_per_host": {
+				Description:  maxIdleConnsPerHostDesc,
+				Type:         schema.TypeInt,
+				Optional:     true,
+				ValidateFunc: validation.IntAtLeast(1),
+				DefaultFunc: schema.MultiEnvDefaultFunc(
+					[]string{"EC_MAX_IDLE_CONNS_PER_HOST"}, 10,
+				),
+			},
+			"max_conns_per_host": {
+				Description:  maxConnsPerHostDesc,
+				Type:         schema.TypeInt,
+				Optional:     true,
+				ValidateFunc: validation.IntAtLeast(0),
+				DefaultFunc: schema.MultiEnvDefaultFunc(
+					[]string{"EC_MAX_CONNS_PER_HOST"}, 0,
+				),
+			},
+			"idle_conn_timeout": {
+				Description: idleConnTimeoutDesc,
+				Type:        schema.TypeString,
+				Optional:    true,
+				DefaultFunc: schema.MultiEnvDefaultFunc(
+					[]string{"EC_IDLE_CONN_TIMEOUT"}, "90s",
+				),
+			},
+			"disable_http2": {
+				Description: disableHTTP2Desc,
+				Type:        schema.TypeBool,
+				Optional:    true,
+				DefaultFunc: schema.MultiEnvDefaultFunc(
+					[]string{"EC_DISABLE_HTTP2"}, false,
+				),
+			},
+			"headers": {
+				Description: headersDesc,
+				Type:        schema.TypeMap,
+				Optional:    true,
+				Elem:        &schema.Schema{Type: schema.TypeString},
+			},
+			"features": {
+				Description: featuresDesc,
+				Type:        schema.TypeList,
+				Optional:    true,
+				MaxItems:    1,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"deployment": {
+							Type:     schema.TypeList,
+							Optional: true,
+							MaxItems: 1,
+							Elem: &schema.Resource{
+								Schema: map[string]*schema.Schema{
+									"skip_final_snapshot": {
+										Description: skipFinalSnapshotDesc,
+										Type:        schema.TypeBool,
+										Optional:    true,
+									},
+									"prune_orphans": {
+										Description: pruneOrphansDesc,
+										Type:        schema.TypeBool,
+										Optional:    true,
+									},
+									"retry_insufficient_capacity": {
+										Description: retryCapacityDesc,
+										Type:        schema.TypeBool,
+										Optional:    true,
+									},
+								},
+							},
+						},
+					},
+				},
+			},
 		},
 		DataSourcesMap: map[string]*schema.Resource{
-			"ec_deployment": deploymentdatasource.DataSource(),
-			"ec_stack":      stackdatasource.DataSource(),
+			"ec_aws_privatelink_endpoint":             privatelinkdatasource.AwsDataSource(),
+			"ec_azure_privatelink_endpoint":           privatelinkdatasource.AzureDataSource(),
+			"ec_deployment":                           deploymentdatasource.DataSource(),
+			"ec_deployments":                          deploymentsdatasource.DataSource(),
+			"ec_deployment_template":                  deploymenttemplatedatasource.DataSource(),
+			"ec_deployment_templates":                 deploymenttemplatesdatasource.DataSource(),
+			"ec_extensions":                           extensionsdatasource.DataSource(),
+			"ec_gcp_private_service_connect_endpoint": privatelinkdatasource.GcpDataSource(),
+			"ec_instance_configurations":              instanceconfigurationsdatasource.DataSource(),
+			"ec_stack":                                stackdatasource.DataSource(),
+			"ec_stack_versions":                       stackversionsdatasource.DataSource(),
+			"ec_traffic_filter":                       trafficfilterdatasource.DataSource(),
+			"ec_traffic_filters":                      trafficfiltersdatasource.DataSource(),
 		},
 		ResourcesMap: map[string]*schema.Resource{
 			"ec_deployment":                            deploymentresource.Resource(),
+			"ec_deployment_elasticsearch_keystore":     keystoreresource.Resource(),
+			"ec_deployment_extension":                  extensionresource.Resource(),
+			"ec_deployment_remote_clusters":            remoteclustersresource.Resource(),
 			"ec_deployment_traffic_filter":             trafficfilterresource.Resource(),
 			"ec_deployment_traffic_filter_association": trafficfilterassocresource.Resource(),
 		},
@@ -19,19 +19,30 @@ package ec
 
 import (
 	"context"
+	"crypto/tls"
 	"fmt"
+	"log"
+	"net"
 	"net/http"
 	"os"
 	"time"
 
 	"github.com/elastic/cloud-sdk-go/pkg/api"
+	"github.com/elastic/cloud-sdk-go/pkg/api/platformapi"
 	"github.com/elastic/cloud-sdk-go/pkg/auth"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+
+	"github.com/elastic/terraform-provider-ec/ec/ectransport"
+	"github.com/elastic/terraform-provider-ec/ec/util"
 )
 
 const (
-	providerUserAgentFmt = "elastic-terraform-provider/%s (%s)"
+	// eceRegion is the region of the ECE installations.
+	eceRegion = "ece-region"
+
+	providerUserAgent    = "elastic-terraform-provider"
+	providerUserAgentFmt = providerUserAgent + "/%s (%s)"
 )
 
 // configureAPI implements schema.ConfigureContextFunc
@@ -52,25 +63,132 @@ func configureAPI(_ context.Context, d *schema.ResourceData) (interface{}, diag.
 		return nil, diag.FromErr(err)
 	}
 
+	transport, err := newHTTPTransport(d, timeout)
+	if err != nil {
+		return nil, diag.FromErr(err)
+	}
+
+	httpClient := &http.Client{Transport: transport}
 	client, err := api.NewAPI(api.Config{
 		ErrorDevice:     os.Stdout,
-		Client:          &http.Client{},
+		Client:          httpClient,
 		VerboseSettings: verboseSettings(d.Get("verbose").(bool)),
 		AuthWriter:      authWriter,
 		Host:            d.Get("endpoint").(string),
 		SkipTLSVerify:   d.Get("insecure").(bool),
 		Timeout:         timeout,
-		UserAgent:       userAgent(Version),
+		UserAgent:       userAgent(Version, d.Get("disable_telemetry").(bool)),
 	})
 
 	if err != nil {
 		return nil, diag.FromErr(err)
 	}
 
-	return client, nil
+	// The transport is wrapped after the API has been created, since api.NewAPI
+	// only applies settings such as "insecure" to an *http.Transport. The client
+	// is shared with the API, so all API calls go through the wrapped transport.
+	var rt http.RoundTripper = ectransport.NewRetryAfterTransport(
+		ectransport.NewLoggingTransport(httpClient.Transport),
+	)
+	if headers := expandHeaders(d.Get("headers").(map[string]interface{})); len(headers) > 0 {
+		rt = ectransport.NewHeadersTransport(rt, headers)
+	}
+	if rate := d.Get("requests_per_second").(float64); rate > 0 {
+		rt = ectransport.NewThrottleTransport(
+			rt, rate, d.Get("requests_burst").(int),
+		)
+	}
+	httpClient.Transport = rt
+
+	endpoint := d.Get("endpoint").(string)
+	meta := util.NewProviderMeta(client, endpoint)
+	meta.Features = expandFeatures(d.Get("features").([]interface{}))
+
+	if endpoint != api.ESSEndpoint {
+		setPlatformVersion(meta)
+	}
+
+	return meta, nil
+}
+
+// setPlatformVersion obtains the version of the targeted ECE installation, so
+// the configured resources can be checked against it when planning. Since
+// the check is a best effort, failing to obtain the version is only logged.
+func setPlatformVersion(meta *util.ProviderMeta) {
+	info, err := platformapi.GetInfo(platformapi.GetInfoParams{
+		API: meta.API, Region: eceRegion,
+	})
+	if err != nil {
+		log.Printf("[WARN] failed obtaining the ECE platform version: %s", err)
+		return
+	}
+
+	if info.Version != nil {
+		meta.PlatformVersion = *info.Version
+	}
+}
+
+func expandHeaders(raw map[string]interface{}) map[string]string {
+	var headers = make(map[string]string, len(raw))
+	for k, v := range raw {
+		headers[k] = v.(string)
+	}
+	return headers
+}
+
+// expandFeatures expands the provider "features" block.
+func expandFeatures(raw []interface{}) util.Features {
+	var features util.Features
+	if len(raw) == 0 || raw[0] == nil {
+		return features
+	}
+
+	m := raw[0].(map[string]interface{})
+	if deployment, ok := m["deployment"].([]interface{}); ok && len(deployment) > 0 && deployment[0] != nil {
+		dm := deployment[0].(map[string]interface{})
+		features.Deployment.SkipFinalSnapshot = dm["skip_final_snapshot"].(bool)
+		features.Deployment.PruneOrphans = dm["prune_orphans"].(bool)
+		features.Deployment.RetryInsufficientCapacity = dm["retry_insufficient_capacity"].(bool)
+	}
+
+	return features
 }
 
-func userAgent(v string) string {
+// newHTTPTransport returns the *http.Transport used for all API calls with the
+// connection pool, keep-alive and HTTP/2 settings tuned from the provider
+// configuration. The defaults favour reusing connections, which avoids
+// exhausting the ephemeral ports when refreshing a large number of resources.
+func newHTTPTransport(d *schema.ResourceData, timeout time.Duration) (*http.Transport, error) {
+	idleConnTimeout, err := time.ParseDuration(d.Get("idle_conn_timeout").(string))
+	if err != nil {
+		return nil, fmt.Errorf("invalid idle_conn_timeout: %w", err)
+	}
+
+	var transport = http.DefaultTransport.(*http.Transport).Clone()
+	transport.DialContext = (&net.Dialer{
+		Timeout:   timeout,
+		KeepAlive: 30 * time.Second,
+	}).DialContext
+	transport.MaxIdleConnsPerHost = d.Get("max_idle_conns_per_host").(int)
+	transport.MaxConnsPerHost = d.Get("max_conns_per_host").(int)
+	transport.IdleConnTimeout = idleConnTimeout
+
+	if d.Get("disable_http2").(bool) {
+		// A non-nil empty map disables the HTTP/2 upgrade.
+		transport.ForceAttemptHTTP2 = false
+		transport.TLSNextProto = make(map[string]func(string, *tls.Conn) http.RoundTripper)
+	}
+
+	return transport, nil
+}
+
+// userAgent returns the User-Agent to send on all outgoing requests. When the
+// telemetry is disabled, the provider and SDK versions are left out.
+func userAgent(v string, disableTelemetry bool) string {
+	if disableTelemetry {
+		return providerUserAgent
+	}
+
 	return fmt.Sprintf(providerUserAgentFmt, v, api.DefaultUserAgent)
 }
 
@@ -0,0 +1,53 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package util
+
+import (
+	"fmt"
+	"strings"
+)
+
+const compositeIDSeparator = "/"
+
+// ParseCompositeID splits a composite import ID made of multiple parts joined
+// by "/" (e.g. "<deployment_id>/<traffic_filter_id>"). The part names are used
+// to build the expected format which is returned as part of the error when
+// the ID doesn't have the expected number of non-empty parts.
+func ParseCompositeID(id string, names ...string) ([]string, error) {
+	var format = make([]string, 0, len(names))
+	for _, name := range names {
+		format = append(format, fmt.Sprintf("<%s>", name))
+	}
+
+	var parts = strings.Split(id, compositeIDSeparator)
+	var invalid = len(parts) != len(names)
+	for _, part := range parts {
+		if strings.TrimSpace(part) == "" {
+			invalid = true
+		}
+	}
+
+	if invalid {
+		return nil, fmt.Errorf(
+			`invalid import id "%s": expected format "%s"`,
+			id, strings.Join(format, compositeIDSeparator),
+		)
+	}
+
+	return parts, nil
+}
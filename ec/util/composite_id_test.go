@@ -0,0 +1,82 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package util
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseCompositeID(t *testing.T) {
+	type args struct {
+		id    string
+		names []string
+	}
+	tests := []struct {
+		name string
+		args args
+		want []string
+		err  error
+	}{
+		{
+			name: "parses a two part id",
+			args: args{
+				id:    "320b7b540dfc967a7a649c18e2fce4ed/420b7b540dfc967a7a649c18e2fce4e4",
+				names: []string{"deployment_id", "traffic_filter_id"},
+			},
+			want: []string{"320b7b540dfc967a7a649c18e2fce4ed", "420b7b540dfc967a7a649c18e2fce4e4"},
+		},
+		{
+			name: "returns an error when the id has less parts",
+			args: args{
+				id:    "320b7b540dfc967a7a649c18e2fce4ed",
+				names: []string{"deployment_id", "traffic_filter_id"},
+			},
+			err: errors.New(`invalid import id "320b7b540dfc967a7a649c18e2fce4ed": expected format "<deployment_id>/<traffic_filter_id>"`),
+		},
+		{
+			name: "returns an error when the id has more parts",
+			args: args{
+				id:    "a/b/c",
+				names: []string{"deployment_id", "setting_name"},
+			},
+			err: errors.New(`invalid import id "a/b/c": expected format "<deployment_id>/<setting_name>"`),
+		},
+		{
+			name: "returns an error when any of the parts is empty",
+			args: args{
+				id:    "a/",
+				names: []string{"deployment_id", "setting_name"},
+			},
+			err: errors.New(`invalid import id "a/": expected format "<deployment_id>/<setting_name>"`),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ParseCompositeID(tt.args.id, tt.args.names...)
+			if tt.err != nil {
+				assert.EqualError(t, err, tt.err.Error())
+			} else {
+				assert.NoError(t, err)
+			}
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
@@ -0,0 +1,50 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package util
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/elastic/cloud-sdk-go/pkg/api"
+)
+
+// essConsoleURL is the Elasticsearch Service console URL.
+const essConsoleURL = "https://cloud.elastic.co"
+
+// consoleURL returns the Cloud console URL from the API endpoint. The
+// Elasticsearch Service API endpoint maps to the Elasticsearch Service
+// console, and ECE installations serve their console from the same endpoint
+// as the API.
+func consoleURL(endpoint string) string {
+	var url = strings.TrimSuffix(endpoint, "/")
+	if url == api.ESSEndpoint {
+		return essConsoleURL
+	}
+	return url
+}
+
+// DeploymentConsoleURL returns the Cloud console URL of the deployment. An
+// empty string is returned when the provider's console URL isn't known.
+func (m *ProviderMeta) DeploymentConsoleURL(id string) string {
+	if m.consoleURL == "" || id == "" {
+		return ""
+	}
+
+	return fmt.Sprintf("%s/deployments/%s", m.consoleURL, id)
+}
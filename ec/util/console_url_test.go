@@ -0,0 +1,54 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package util
+
+import (
+	"testing"
+
+	"github.com/elastic/cloud-sdk-go/pkg/api"
+	"github.com/elastic/cloud-sdk-go/pkg/api/mock"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDeploymentConsoleURL(t *testing.T) {
+	tests := []struct {
+		name     string
+		endpoint string
+		want     string
+	}{
+		{
+			name:     "returns the Elasticsearch Service console URL",
+			endpoint: api.ESSEndpoint,
+			want:     "https://cloud.elastic.co/deployments/" + mock.ValidClusterID,
+		},
+		{
+			name:     "returns the ECE console URL",
+			endpoint: "https://ece.example.com:12443/",
+			want:     "https://ece.example.com:12443/deployments/" + mock.ValidClusterID,
+		},
+		{
+			name: "returns an empty URL when the provider's console URL isn't known",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			meta := NewProviderMeta(api.NewMock(), tt.endpoint)
+			assert.Equal(t, tt.want, meta.DeploymentConsoleURL(mock.ValidClusterID))
+		})
+	}
+}
@@ -0,0 +1,97 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package util
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+
+	"github.com/elastic/cloud-sdk-go/pkg/api"
+	"github.com/elastic/cloud-sdk-go/pkg/api/apierror"
+	"github.com/elastic/cloud-sdk-go/pkg/api/deploymentapi/deputil"
+	"github.com/elastic/cloud-sdk-go/pkg/client/deployments"
+	"github.com/elastic/cloud-sdk-go/pkg/models"
+	"github.com/elastic/cloud-sdk-go/pkg/util/ec"
+	"github.com/go-openapi/runtime"
+)
+
+var (
+	// ErrUnauthenticated is returned when the API rejects the configured
+	// credentials (HTTP 401).
+	ErrUnauthenticated = errors.New(
+		"authentication failed: the configured apikey or username and password are invalid or have expired",
+	)
+
+	// ErrForbidden is returned when the configured credentials are valid but
+	// lack the permissions for the operation (HTTP 403).
+	ErrForbidden = errors.New(
+		"authorization failed: the configured credentials lack the permissions to access the deployment",
+	)
+
+	// ErrDeploymentNotFound is returned when the deployment doesn't exist
+	// (HTTP 404).
+	ErrDeploymentNotFound = errors.New(
+		"deployment not found: it may have been deleted outside of terraform or belong to a different organization",
+	)
+)
+
+// GetDeployment obtains the deployment matching the ID. Unlike deploymentapi.Get,
+// authentication, authorization and not found responses are returned as
+// distinct errors which can be checked with errors.Is.
+func GetDeployment(client *api.API, id string, params deputil.QueryParams) (*models.DeploymentGetResponse, error) {
+	res, err := client.V1API.Deployments.GetDeployment(
+		deployments.NewGetDeploymentParams().
+			WithDeploymentID(id).
+			WithShowPlans(ec.Bool(params.ShowPlans)).
+			WithShowPlanDefaults(ec.Bool(params.ShowPlanDefaults)).
+			WithShowPlanLogs(ec.Bool(params.ShowPlanLogs)).
+			WithShowPlanHistory(ec.Bool(params.ShowPlanHistory)).
+			WithShowMetadata(ec.Bool(params.ShowMetadata)).
+			WithShowSettings(ec.Bool(params.ShowSettings)),
+		client.AuthWriter,
+	)
+	if err != nil {
+		return nil, deploymentReadError(id, err)
+	}
+
+	return res.Payload, nil
+}
+
+func deploymentReadError(id string, err error) error {
+	var sentinel error
+	switch e := err.(type) {
+	case *deployments.GetDeploymentUnauthorized:
+		sentinel = ErrUnauthenticated
+	case *deployments.GetDeploymentNotFound:
+		sentinel = ErrDeploymentNotFound
+	case *runtime.APIError:
+		switch e.Code {
+		case http.StatusUnauthorized:
+			sentinel = ErrUnauthenticated
+		case http.StatusForbidden:
+			sentinel = ErrForbidden
+		}
+	}
+
+	if sentinel == nil {
+		return apierror.Unwrap(err)
+	}
+
+	return fmt.Errorf("deployment %s: %w: %s", id, sentinel, apierror.Unwrap(err))
+}
@@ -0,0 +1,82 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package util
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/elastic/cloud-sdk-go/pkg/client/deployments"
+	"github.com/elastic/cloud-sdk-go/pkg/models"
+	"github.com/elastic/cloud-sdk-go/pkg/util/ec"
+	"github.com/go-openapi/runtime"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_deploymentReadError(t *testing.T) {
+	failedReply := func(code, message string) *models.BasicFailedReply {
+		return &models.BasicFailedReply{Errors: []*models.BasicFailedReplyElement{
+			{Code: ec.String(code), Message: ec.String(message)},
+		}}
+	}
+	tests := []struct {
+		name    string
+		err     error
+		want    error
+		wantMsg string
+	}{
+		{
+			name: "unauthorized response returns ErrUnauthenticated",
+			err: &deployments.GetDeploymentUnauthorized{
+				Payload: failedReply("root.unauthorized", "The supplied authentication is invalid"),
+			},
+			want:    ErrUnauthenticated,
+			wantMsg: "deployment 123: " + ErrUnauthenticated.Error(),
+		},
+		{
+			name: "not found response returns ErrDeploymentNotFound",
+			err: &deployments.GetDeploymentNotFound{
+				Payload: failedReply("deployments.deployment_not_found", "Deployment not found"),
+			},
+			want:    ErrDeploymentNotFound,
+			wantMsg: "deployment 123: " + ErrDeploymentNotFound.Error(),
+		},
+		{
+			name:    "forbidden response returns ErrForbidden",
+			err:     runtime.NewAPIError("getDeployment", nil, 403),
+			want:    ErrForbidden,
+			wantMsg: "deployment 123: " + ErrForbidden.Error(),
+		},
+		{
+			name: "other errors are unwrapped as is",
+			err:  errors.New("some error"),
+			want: errors.New("some error"),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := deploymentReadError("123", tt.err)
+			if tt.wantMsg == "" {
+				assert.Equal(t, tt.want, err)
+				return
+			}
+			assert.True(t, errors.Is(err, tt.want))
+			assert.Contains(t, err.Error(), tt.wantMsg)
+		})
+	}
+}
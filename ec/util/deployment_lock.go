@@ -0,0 +1,43 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package util
+
+import "sync"
+
+var deploymentLocks = struct {
+	sync.Mutex
+	entries map[string]*sync.Mutex
+}{entries: make(map[string]*sync.Mutex)}
+
+// LockDeployment acquires the lock for the deployment ID, blocking until it's
+// available, and returns the function which releases it. It must be held by
+// any resource mutating a deployment (i.e. the deployment itself or its
+// traffic filter associations), so that resources targeting the same
+// deployment don't run conflicting changes concurrently.
+func LockDeployment(id string) (unlock func()) {
+	deploymentLocks.Lock()
+	mu, ok := deploymentLocks.entries[id]
+	if !ok {
+		mu = new(sync.Mutex)
+		deploymentLocks.entries[id] = mu
+	}
+	deploymentLocks.Unlock()
+
+	mu.Lock()
+	return mu.Unlock
+}
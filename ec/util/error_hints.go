@@ -0,0 +1,137 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package util
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+)
+
+// errorHint describes the remediation steps for a frequent API failure.
+type errorHint struct {
+	// limit is the kind of the LimitError the failure type belongs to. When
+	// set, the failure type is matched on the API error codes rather than the
+	// error message.
+	limit string
+	// match returns true when the lower cased error message belongs to the
+	// failure type.
+	match       func(msg string) bool
+	remediation string
+	// docs is an optional documentation link.
+	docs string
+}
+
+// errorCatalog contains the frequent API failure types which are translated
+// into diagnostics with concrete remediation steps. The first match wins.
+var errorCatalog = []errorHint{
+	{
+		limit: LimitCapacity,
+		remediation: "The region doesn't have enough capacity for the requested topology. " +
+			"Reduce the size or zone_count of the topology elements, retry later, " +
+			"or create the deployment in a different region.",
+		docs: "https://www.elastic.co/guide/en/cloud/current/ec-customize-deployment.html",
+	},
+	{
+		limit: LimitDeployments,
+		remediation: "The organization has reached its maximum number of deployments. " +
+			"Delete the deployments which are no longer used, or contact Elastic support to raise the limit.",
+	},
+	{
+		match: containsAny("instance_configuration", "instance configuration"),
+		remediation: "The instance_configuration_id of a topology element isn't part of the deployment template. " +
+			"Remove the instance_configuration_id to use the template's default, " +
+			"or use one of the instance configurations of the deployment_template_id in the region.",
+		docs: "https://www.elastic.co/guide/en/cloud/current/ec-regions-templates-instances.html",
+	},
+	{
+		match: func(msg string) bool {
+			return strings.Contains(msg, "version") &&
+				containsAny("not available", "not found", "unsupported", "not supported", "does not exist")(msg)
+		},
+		remediation: "The Elastic Stack version isn't available in the region. " +
+			"Use the ec_stack data source to obtain a version which is available in the region.",
+		docs: "https://www.elastic.co/guide/en/cloud/current/ec-version-policy.html",
+	},
+	{
+		limit: LimitTrafficFilter,
+		remediation: "The maximum number of traffic filter rulesets or associations has been reached. " +
+			"Remove unused ec_deployment_traffic_filter resources, or reuse an existing ruleset " +
+			"across deployments instead of creating one per deployment.",
+		docs: "https://www.elastic.co/guide/en/cloud/current/ec-traffic-filtering-deployment-configuration.html",
+	},
+}
+
+// ErrorDiagnostics returns the diagnostics for the specified error. When the
+// error matches one of the frequent API failure types, the diagnostic detail
+// contains the steps to remediate it and a documentation link.
+func ErrorDiagnostics(err error) diag.Diagnostics {
+	if err == nil {
+		return nil
+	}
+
+	var details []string
+	if limitErr, ok := AsLimitError(err); ok {
+		if usage := limitErr.describeUsage(); usage != "" {
+			details = append(details, usage)
+		}
+	}
+
+	if hint := findErrorHint(err); hint != nil {
+		details = append(details, hint.remediation)
+		if hint.docs != "" {
+			details = append(details, fmt.Sprintf("See %s for more information.", hint.docs))
+		}
+	}
+
+	diags := diag.FromErr(err)
+	diags[0].Detail = strings.Join(details, "\n\n")
+
+	return diags
+}
+
+func findErrorHint(err error) *errorHint {
+	msg := strings.ToLower(err.Error())
+	limitErr, isLimit := AsLimitError(err)
+	for i := range errorCatalog {
+		hint := &errorCatalog[i]
+		if hint.limit != "" {
+			if isLimit && limitErr.Kind == hint.limit {
+				return hint
+			}
+			continue
+		}
+
+		if hint.match(msg) {
+			return hint
+		}
+	}
+	return nil
+}
+
+func containsAny(substrs ...string) func(string) bool {
+	return func(msg string) bool {
+		for _, s := range substrs {
+			if strings.Contains(msg, s) {
+				return true
+			}
+		}
+		return false
+	}
+}
@@ -0,0 +1,108 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package util
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestErrorDiagnostics(t *testing.T) {
+	type args struct {
+		err error
+	}
+	tests := []struct {
+		name string
+		args args
+		want diag.Diagnostics
+	}{
+		{
+			name: "returns no diagnostics without an error",
+		},
+		{
+			name: "returns the error without a remediation when it's unknown",
+			args: args{err: errors.New("api error: some.code: something went wrong")},
+			want: diag.Diagnostics{{
+				Severity: diag.Error,
+				Summary:  "api error: some.code: something went wrong",
+			}},
+		},
+		{
+			name: "returns the error without a remediation on a timeout",
+			args: args{err: errors.New("failed creating deployment: context deadline exceeded")},
+			want: diag.Diagnostics{{
+				Severity: diag.Error,
+				Summary:  "failed creating deployment: context deadline exceeded",
+			}},
+		},
+		{
+			name: "returns the remediation for insufficient capacity",
+			args: args{err: errors.New("api error: clusters.cluster_plan_state_error: Not enough Capacity to allocate the instances")},
+			want: diag.Diagnostics{{
+				Severity: diag.Error,
+				Summary:  "api error: clusters.cluster_plan_state_error: Not enough Capacity to allocate the instances",
+				Detail:   errorCatalog[0].remediation + "\n\nSee " + errorCatalog[0].docs + " for more information.",
+			}},
+		},
+		{
+			name: "returns the usage and remediation for the deployments limit",
+			args: args{err: errors.New("api error: deployments.limit_exceeded: 50 of 50 deployments in use")},
+			want: diag.Diagnostics{{
+				Severity: diag.Error,
+				Summary:  "api error: deployments.limit_exceeded: 50 of 50 deployments in use",
+				Detail:   "Current deployments usage: 50, limit: 50.\n\n" + errorCatalog[1].remediation,
+			}},
+		},
+		{
+			name: "returns the remediation for an invalid instance configuration",
+			args: args{err: errors.New("api error: deployments.invalid_instance_configuration: aws.data.highio.i3 doesn't belong to the template")},
+			want: diag.Diagnostics{{
+				Severity: diag.Error,
+				Summary:  "api error: deployments.invalid_instance_configuration: aws.data.highio.i3 doesn't belong to the template",
+				Detail:   errorCatalog[2].remediation + "\n\nSee " + errorCatalog[2].docs + " for more information.",
+			}},
+		},
+		{
+			name: "returns the remediation for an unavailable version",
+			args: args{err: errors.New("api error: stackpack.version_not_found: version 7.99.0 not found")},
+			want: diag.Diagnostics{{
+				Severity: diag.Error,
+				Summary:  "api error: stackpack.version_not_found: version 7.99.0 not found",
+				Detail:   errorCatalog[3].remediation + "\n\nSee " + errorCatalog[3].docs + " for more information.",
+			}},
+		},
+		{
+			name: "returns the remediation for the traffic filter limit",
+			args: args{err: errors.New("api error: traffic_filter.limit_exceeded: maximum number of rulesets reached")},
+			want: diag.Diagnostics{{
+				Severity: diag.Error,
+				Summary:  "api error: traffic_filter.limit_exceeded: maximum number of rulesets reached",
+				Detail:   errorCatalog[4].remediation + "\n\nSee " + errorCatalog[4].docs + " for more information.",
+			}},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := ErrorDiagnostics(tt.args.err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
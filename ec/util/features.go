@@ -0,0 +1,40 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package util
+
+// Features contains the opt-in behaviors set in the provider "features"
+// block, which apply to all the resources managed by the provider.
+type Features struct {
+	Deployment DeploymentFeatures
+}
+
+// DeploymentFeatures contains the opt-in behaviors of the ec_deployment
+// resource.
+type DeploymentFeatures struct {
+	// SkipFinalSnapshot skips the snapshot which is taken before the
+	// deployment is shut down on destroy.
+	SkipFinalSnapshot bool
+
+	// PruneOrphans removes the deployment resources which aren't part of the
+	// configuration on update.
+	PruneOrphans bool
+
+	// RetryInsufficientCapacity retries the deployment create and update
+	// calls which fail because there isn't enough capacity.
+	RetryInsufficientCapacity bool
+}
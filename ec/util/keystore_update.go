@@ -0,0 +1,77 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package util
+
+import (
+	"github.com/elastic/cloud-sdk-go/pkg/api"
+	"github.com/elastic/cloud-sdk-go/pkg/api/apierror"
+	"github.com/elastic/cloud-sdk-go/pkg/api/deploymentapi"
+	"github.com/elastic/cloud-sdk-go/pkg/client/deployments"
+	"github.com/elastic/cloud-sdk-go/pkg/models"
+	sdkutil "github.com/elastic/cloud-sdk-go/pkg/util"
+	"github.com/go-openapi/runtime"
+	"github.com/go-openapi/strfmt"
+)
+
+// KeystoreUpdate is the partial Elasticsearch keystore contents sent to the
+// keystore API. Unlike models.KeystoreContents, its secrets can be null, which
+// is how the API expects them to be removed, i.e.
+// {"secrets": {"<setting_name>": null}}. An empty secret isn't a removal.
+type KeystoreUpdate struct {
+	Secrets map[string]*models.KeystoreSecret `json:"secrets"`
+}
+
+// UpdateKeystore sends the partial keystore contents to the deployment's
+// Elasticsearch keystore. The keystore update operation is submitted with a
+// custom body, since eskeystoreapi.Update can't represent the null secrets.
+func UpdateKeystore(client *api.API, deploymentID string, body *KeystoreUpdate) error {
+	var refID string
+	if err := deploymentapi.PopulateRefID(deploymentapi.PopulateRefIDParams{
+		API:          client,
+		DeploymentID: deploymentID,
+		RefID:        &refID,
+		Kind:         sdkutil.Elasticsearch,
+	}); err != nil {
+		return err
+	}
+
+	params := deployments.NewSetDeploymentEsResourceKeystoreParams().
+		WithDeploymentID(deploymentID).
+		WithRefID(refID)
+
+	_, err := client.V1API.Transport.Submit(&runtime.ClientOperation{
+		ID:                 "set-deployment-es-resource-keystore",
+		Method:             "PATCH",
+		PathPattern:        "/deployments/{deployment_id}/elasticsearch/{ref_id}/keystore",
+		ProducesMediaTypes: []string{"application/json"},
+		ConsumesMediaTypes: []string{"application/json"},
+		Schemes:            []string{"https"},
+		Params: runtime.ClientRequestWriterFunc(func(r runtime.ClientRequest, reg strfmt.Registry) error {
+			if err := params.WriteToRequest(r, reg); err != nil {
+				return err
+			}
+			return r.SetBodyParam(body)
+		}),
+		Reader:   &deployments.SetDeploymentEsResourceKeystoreReader{},
+		AuthInfo: client.AuthWriter,
+		Context:  params.Context,
+		Client:   params.HTTPClient,
+	})
+
+	return apierror.Unwrap(err)
+}
@@ -0,0 +1,224 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package util
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/elastic/cloud-sdk-go/pkg/models"
+	"github.com/elastic/cloud-sdk-go/pkg/multierror"
+)
+
+// Organization quota and limit kinds.
+const (
+	LimitDeployments   = "deployments"
+	LimitCapacity      = "capacity"
+	LimitTrafficFilter = "traffic filter"
+)
+
+// apiErrorPrefix is the prefix of the errors unwrapped from the API responses
+// by apierror.Unwrap, which are formatted as "<code>: <message>".
+const apiErrorPrefix = "api error"
+
+var (
+	apiErrorElementRegexp = regexp.MustCompile(`^([a-z][a-z0-9_]*(?:\.[a-z0-9_]+)+): (.*)`)
+	usageOfLimitRegexp    = regexp.MustCompile(`(\d+)\s+(?:out\s+)?of\s+(\d+)`)
+	limitRegexp           = regexp.MustCompile(`(?:limit|maximum|quota)\s+(?:of\s+|is\s+)?(\d+)`)
+)
+
+// LimitError is an API error caused by an organization quota or limit, such
+// as the maximum number of deployments or the available capacity.
+type LimitError struct {
+	// Kind is the quota or limit which has been reached.
+	Kind string
+
+	// Usage and Limit are the current usage and the limit, they're zero when
+	// the API error doesn't include them.
+	Usage int
+	Limit int
+
+	Err error
+}
+
+func (e *LimitError) Error() string {
+	return e.Err.Error()
+}
+
+// Unwrap returns the API error.
+func (e *LimitError) Unwrap() error { return e.Err }
+
+// Transient returns true when the limit may be lifted without any action,
+// i.e. when the capacity is freed up by the platform.
+func (e *LimitError) Transient() bool { return e.Kind == LimitCapacity }
+
+// describeUsage returns the current usage and the limit in a human readable
+// form, or an empty string when the API error doesn't include them.
+func (e *LimitError) describeUsage() string {
+	switch {
+	case e.Limit > 0 && e.Usage > 0:
+		return fmt.Sprintf("Current %s usage: %d, limit: %d.", e.Kind, e.Usage, e.Limit)
+	case e.Limit > 0:
+		return fmt.Sprintf("Current %s limit: %d.", e.Kind, e.Limit)
+	}
+	return ""
+}
+
+// AsLimitError returns the LimitError describing the error when it's caused by
+// an organization quota or limit. Only the API error codes are classified, so
+// errors which don't come from the API, such as timeouts, are never reported
+// as limits.
+func AsLimitError(err error) (*LimitError, bool) {
+	if err == nil {
+		return nil, false
+	}
+
+	var limitErr *LimitError
+	if errors.As(err, &limitErr) {
+		return limitErr, true
+	}
+
+	for _, elem := range apiErrorElements(err) {
+		kind := limitKind(elem.code, elem.message)
+		if kind == "" {
+			continue
+		}
+
+		limitErr = &LimitError{Kind: kind, Err: err}
+		msg := strings.ToLower(elem.message)
+		if m := usageOfLimitRegexp.FindStringSubmatch(msg); m != nil {
+			limitErr.Usage, _ = strconv.Atoi(m[1])
+			limitErr.Limit, _ = strconv.Atoi(m[2])
+		} else if m := limitRegexp.FindStringSubmatch(msg); m != nil {
+			limitErr.Limit, _ = strconv.Atoi(m[1])
+		}
+
+		return limitErr, true
+	}
+
+	return nil, false
+}
+
+// limitKind returns the kind of limit the API error code belongs to, or an
+// empty string when it isn't caused by a limit.
+func limitKind(code, message string) string {
+	var namespace, name = code, ""
+	if i := strings.Index(code, "."); i > 0 {
+		namespace, name = code[:i], code[i+1:]
+	}
+
+	switch {
+	case strings.Contains(name, "capacity"):
+		return LimitCapacity
+	// The missing capacity is reported as a failure to apply the plan, which
+	// is told apart from the other plan failures by its message.
+	case namespace == "clusters" && strings.Contains(name, "plan") &&
+		strings.Contains(strings.ToLower(message), "capacity"):
+		return LimitCapacity
+	case !containsAny("limit", "quota", "maximum", "exceeded")(name):
+		return ""
+	case strings.HasPrefix(namespace, "traffic_filter"):
+		return LimitTrafficFilter
+	case strings.HasPrefix(namespace, "deployment"):
+		return LimitDeployments
+	}
+	return ""
+}
+
+// apiErrorElement is an error element of an API error response.
+type apiErrorElement struct {
+	code    string
+	message string
+}
+
+// apiErrorElements returns the error elements of the API error response which
+// caused the error. It reads the response payload when the error hasn't been
+// unwrapped, and the "<code>: <message>" errors created by apierror.Unwrap
+// otherwise, including the ones which have been prefixed by a multierror.
+func apiErrorElements(err error) []apiErrorElement {
+	if reply := basicFailedReply(err); reply != nil {
+		var result = make([]apiErrorElement, 0, len(reply.Errors))
+		for _, e := range reply.Errors {
+			if e == nil || e.Code == nil {
+				continue
+			}
+			var elem = apiErrorElement{code: *e.Code}
+			if e.Message != nil {
+				elem.message = *e.Message
+			}
+			result = append(result, elem)
+		}
+		return result
+	}
+
+	var msgs []string
+	var prefixed *multierror.Prefixed
+	if errors.As(err, &prefixed) {
+		for _, e := range prefixed.Errors {
+			if prefixed.Prefix == apiErrorPrefix {
+				msgs = append(msgs, e.Error())
+			} else {
+				msgs = append(msgs, trimAPIErrorPrefix(e.Error())...)
+			}
+		}
+	} else {
+		msgs = trimAPIErrorPrefix(err.Error())
+	}
+
+	var result []apiErrorElement
+	for _, msg := range msgs {
+		if m := apiErrorElementRegexp.FindStringSubmatch(msg); m != nil {
+			result = append(result, apiErrorElement{code: m[1], message: m[2]})
+		}
+	}
+	return result
+}
+
+// trimAPIErrorPrefix returns the messages which follow the API error prefix.
+func trimAPIErrorPrefix(msg string) []string {
+	var result []string
+	for _, part := range strings.Split(msg, apiErrorPrefix+": ")[1:] {
+		result = append(result, strings.SplitN(part, "\n", 2)[0])
+	}
+	return result
+}
+
+// basicFailedReply returns the API error response payload of the error, or nil
+// when the error isn't an API error response.
+func basicFailedReply(err error) *models.BasicFailedReply {
+	for ; err != nil; err = errors.Unwrap(err) {
+		v := reflect.ValueOf(err)
+		if v.Kind() != reflect.Ptr || v.IsNil() || v.Elem().Kind() != reflect.Struct {
+			continue
+		}
+
+		payload := v.Elem().FieldByName("Payload")
+		if !payload.IsValid() || !payload.CanInterface() {
+			continue
+		}
+
+		if reply, ok := payload.Interface().(*models.BasicFailedReply); ok && reply != nil {
+			return reply
+		}
+	}
+	return nil
+}
@@ -0,0 +1,126 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package util
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/elastic/cloud-sdk-go/pkg/client/deployments"
+	"github.com/elastic/cloud-sdk-go/pkg/models"
+	"github.com/elastic/cloud-sdk-go/pkg/multierror"
+	"github.com/elastic/cloud-sdk-go/pkg/util/ec"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAsLimitError(t *testing.T) {
+	unwrappedDeploymentsLimit := multierror.NewPrefixed("api error",
+		errors.New("deployments.limit_exceeded: 50 of 50 deployments in use"),
+	)
+	prefixedDeploymentsLimit := multierror.NewPrefixed("failed creating deployment",
+		unwrappedDeploymentsLimit,
+	)
+	capacityResponse := &deployments.CreateDeploymentBadRequest{Payload: &models.BasicFailedReply{
+		Errors: []*models.BasicFailedReplyElement{{
+			Code:    ec.String("deployments.insufficient_capacity"),
+			Message: ec.String("not enough capacity to allocate the instances"),
+		}},
+	}}
+
+	tests := []struct {
+		name string
+		err  error
+		want *LimitError
+	}{
+		{
+			name: "returns false without an error",
+		},
+		{
+			name: "returns false when the error isn't caused by a limit",
+			err:  errors.New("api error: some.code: something went wrong"),
+		},
+		{
+			name: "returns the deployments limit with its usage",
+			err:  errors.New("api error: deployments.limit_exceeded: 50 of 50 deployments in use"),
+			want: &LimitError{
+				Kind: LimitDeployments, Usage: 50, Limit: 50,
+				Err: errors.New("api error: deployments.limit_exceeded: 50 of 50 deployments in use"),
+			},
+		},
+		{
+			name: "returns the traffic filter limit",
+			err:  errors.New("api error: traffic_filter.limit_exceeded: the maximum of 100 rulesets has been reached"),
+			want: &LimitError{
+				Kind: LimitTrafficFilter, Limit: 100,
+				Err: errors.New("api error: traffic_filter.limit_exceeded: the maximum of 100 rulesets has been reached"),
+			},
+		},
+		{
+			name: "returns the transient capacity limit",
+			err:  errors.New("api error: clusters.cluster_plan_state_error: not enough capacity"),
+			want: &LimitError{
+				Kind: LimitCapacity,
+				Err:  errors.New("api error: clusters.cluster_plan_state_error: not enough capacity"),
+			},
+		},
+		{
+			name: "returns false on a timeout whose message mentions a deployment",
+			err:  errors.New("failed creating deployment: context deadline exceeded"),
+		},
+		{
+			name: "returns false on an HTTP client timeout",
+			err: errors.New(`Post "https://api.elastic-cloud.com/api/v1/deployments": ` +
+				`net/http: request canceled (Client.Timeout exceeded while awaiting headers)`),
+		},
+		{
+			name: "returns false when only the message mentions the capacity",
+			err:  errors.New("api error: deployments.invalid_topology: the capacity must be a multiple of 1g"),
+		},
+		{
+			name: "returns the deployments limit of an unwrapped API error",
+			err:  unwrappedDeploymentsLimit,
+			want: &LimitError{Kind: LimitDeployments, Usage: 50, Limit: 50, Err: unwrappedDeploymentsLimit},
+		},
+		{
+			name: "returns the deployments limit of a prefixed API error",
+			err:  prefixedDeploymentsLimit,
+			want: &LimitError{Kind: LimitDeployments, Usage: 50, Limit: 50, Err: prefixedDeploymentsLimit},
+		},
+		{
+			name: "returns the capacity limit of an API error response",
+			err:  capacityResponse,
+			want: &LimitError{Kind: LimitCapacity, Err: capacityResponse},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, ok := AsLimitError(tt.err)
+			assert.Equal(t, tt.want != nil, ok)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+
+	t.Run("returns a wrapped LimitError", func(t *testing.T) {
+		limitErr := &LimitError{Kind: LimitCapacity, Err: errors.New("not enough capacity")}
+		got, ok := AsLimitError(fmt.Errorf("failed creating deployment: %w", limitErr))
+		assert.True(t, ok)
+		assert.True(t, got.Transient())
+		assert.Equal(t, limitErr, got)
+	})
+}
@@ -33,18 +33,23 @@ func MemoryToState(mem int32) string {
 	return fmt.Sprintf("%dg", mem/1024)
 }
 
-// ParseTopologySize parses a flattened topology into its model.
+// ParseTopologySize parses a flattened topology into its model. An unset
+// memory_per_node returns an empty size, to be filled from the deployment
+// template. The size applies to the size_resource, which defaults to memory.
 func ParseTopologySize(topology map[string]interface{}) (models.TopologySize, error) {
-	if mem, ok := topology["memory_per_node"]; ok {
+	if mem, ok := topology["memory_per_node"]; ok && mem.(string) != "" {
 		val, err := deploymentsize.ParseGb(mem.(string))
 		if err != nil {
 			return models.TopologySize{}, err
 		}
 
+		var resource = "memory"
+		if r, ok := topology["size_resource"].(string); ok && r != "" {
+			resource = r
+		}
+
 		return models.TopologySize{
-			// TODO: For now the resource is assumed to be "memory". This can
-			// and will change in the future, we need to accommodate for this case.
-			Value: ec.Int32(val), Resource: ec.String("memory"),
+			Value: ec.Int32(val), Resource: ec.String(resource),
 		}, nil
 	}
 
@@ -20,6 +20,8 @@ package util
 import (
 	"testing"
 
+	"github.com/elastic/cloud-sdk-go/pkg/models"
+	"github.com/elastic/cloud-sdk-go/pkg/util/ec"
 	"github.com/stretchr/testify/assert"
 )
 
@@ -50,3 +52,60 @@ func TestMemoryToState(t *testing.T) {
 		})
 	}
 }
+
+func TestParseTopologySize(t *testing.T) {
+	type args struct {
+		topology map[string]interface{}
+	}
+	tests := []struct {
+		name string
+		args args
+		want models.TopologySize
+		err  bool
+	}{
+		{
+			name: "parses the memory per node",
+			args: args{topology: map[string]interface{}{"memory_per_node": "2g"}},
+			want: models.TopologySize{
+				Value: ec.Int32(2048), Resource: ec.String("memory"),
+			},
+		},
+		{
+			name: "parses the storage size",
+			args: args{topology: map[string]interface{}{
+				"memory_per_node": "64g",
+				"size_resource":   "storage",
+			}},
+			want: models.TopologySize{
+				Value: ec.Int32(65536), Resource: ec.String("storage"),
+			},
+		},
+		{
+			name: "unset memory per node returns an empty size",
+			args: args{topology: map[string]interface{}{"memory_per_node": ""}},
+			want: models.TopologySize{},
+		},
+		{
+			name: "missing memory per node returns an empty size",
+			args: args{topology: map[string]interface{}{}},
+			want: models.TopologySize{},
+		},
+		{
+			name: "invalid memory per node returns an error",
+			args: args{topology: map[string]interface{}{"memory_per_node": "two"}},
+			want: models.TopologySize{},
+			err:  true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ParseTopologySize(tt.args.topology)
+			if tt.err {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+			}
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
@@ -0,0 +1,53 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package util
+
+import (
+	"github.com/elastic/cloud-sdk-go/pkg/api"
+)
+
+// ProviderMeta is the meta of the configured provider, which is passed to all
+// the resources and data sources. Besides the API client, it holds the
+// provider settings and the API responses which are memoized for the duration
+// of the plan or apply, since the provider process doesn't outlive them.
+type ProviderMeta struct {
+	// API is the Elastic Cloud API client.
+	API *api.API
+
+	// Features contains the opt-in behaviors set in the provider "features"
+	// block.
+	Features Features
+
+	// PlatformVersion is the ECE platform version of the installation the
+	// provider targets, or an empty string when it's unknown, e.g. when it
+	// targets the Elasticsearch Service.
+	PlatformVersion string
+
+	consoleURL     string
+	stackVersions  stackVersionsCache
+	trafficFilters trafficFilterCache
+}
+
+// NewProviderMeta returns the meta of a provider which uses the API client to
+// target the endpoint.
+func NewProviderMeta(client *api.API, endpoint string) *ProviderMeta {
+	return &ProviderMeta{
+		API:        client,
+		consoleURL: consoleURL(endpoint),
+	}
+}
@@ -0,0 +1,37 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package util
+
+import (
+	"testing"
+
+	"github.com/elastic/cloud-sdk-go/pkg/api"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewProviderMeta(t *testing.T) {
+	client := api.NewMock()
+	got := NewProviderMeta(client, api.ESSEndpoint)
+
+	assert.Equal(t, client, got.API)
+	assert.Equal(t, essConsoleURL, got.consoleURL)
+
+	// The features and platform version default to their zero values.
+	assert.Equal(t, Features{}, got.Features)
+	assert.Equal(t, "", got.PlatformVersion)
+}
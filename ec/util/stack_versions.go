@@ -0,0 +1,72 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package util
+
+import (
+	"sync"
+
+	"github.com/elastic/cloud-sdk-go/pkg/api/stackapi"
+	"github.com/elastic/cloud-sdk-go/pkg/models"
+)
+
+type stackVersionsCache struct {
+	sync.Mutex
+	entries map[string]*stackVersionsEntry
+}
+
+type stackVersionsEntry struct {
+	once sync.Once
+	res  *models.StackVersionConfigs
+	err  error
+}
+
+// ListStackVersions returns the stack versions which are available in the
+// region. The list is only obtained once per region and shared by all the
+// resources and data sources of the provider. Concurrent calls wait for the
+// first one to complete, and failed calls aren't cached.
+func (m *ProviderMeta) ListStackVersions(region string) (*models.StackVersionConfigs, error) {
+	var cache = &m.stackVersions
+
+	cache.Lock()
+	if cache.entries == nil {
+		cache.entries = make(map[string]*stackVersionsEntry)
+	}
+	entry, ok := cache.entries[region]
+	if !ok {
+		entry = new(stackVersionsEntry)
+		cache.entries[region] = entry
+	}
+	cache.Unlock()
+
+	entry.once.Do(func() {
+		entry.res, entry.err = stackapi.List(stackapi.ListParams{
+			API:    m.API,
+			Region: region,
+		})
+	})
+
+	if entry.err != nil {
+		cache.Lock()
+		if cache.entries[region] == entry {
+			delete(cache.entries, region)
+		}
+		cache.Unlock()
+	}
+
+	return entry.res, entry.err
+}
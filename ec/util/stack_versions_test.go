@@ -0,0 +1,61 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package util
+
+import (
+	"testing"
+
+	"github.com/elastic/cloud-sdk-go/pkg/api"
+	"github.com/elastic/cloud-sdk-go/pkg/api/mock"
+	"github.com/elastic/cloud-sdk-go/pkg/models"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestListStackVersions(t *testing.T) {
+	var stacks = models.StackVersionConfigs{Stacks: []*models.StackVersionConfig{
+		{Version: "7.9.1"}, {Version: "7.9.0"},
+	}}
+
+	t.Run("only calls the API once per region", func(t *testing.T) {
+		// The mock only holds one response, any subsequent calls fail.
+		meta := NewProviderMeta(api.NewMock(mock.New200StructResponse(stacks)), "")
+
+		for i := 0; i < 3; i++ {
+			res, err := meta.ListStackVersions("us-east-1")
+			assert.NoError(t, err)
+			assert.Equal(t, &stacks, res)
+		}
+
+		_, err := meta.ListStackVersions("us-west-2")
+		assert.Error(t, err)
+	})
+
+	t.Run("doesn't cache errors", func(t *testing.T) {
+		meta := NewProviderMeta(api.NewMock(
+			mock.New500Response(mock.NewStringBody(`{"errors": []}`)),
+			mock.New200StructResponse(stacks),
+		), "")
+
+		_, err := meta.ListStackVersions("us-east-1")
+		assert.Error(t, err)
+
+		res, err := meta.ListStackVersions("us-east-1")
+		assert.NoError(t, err)
+		assert.Equal(t, &stacks, res)
+	})
+}
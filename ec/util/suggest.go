@@ -0,0 +1,71 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package util
+
+// ClosestMatch returns the candidate which is the closest to the value, as long
+// as it's close enough to be a likely typo: no more than a quarter of the
+// value's characters, with a minimum of two, need to be edited.
+func ClosestMatch(value string, candidates []string) (string, bool) {
+	var maxDistance = len(value) / 4
+	if maxDistance < 2 {
+		maxDistance = 2
+	}
+
+	var match string
+	var matchDistance = maxDistance + 1
+	for _, c := range candidates {
+		if d := editDistance(value, c); d < matchDistance {
+			match, matchDistance = c, d
+		}
+	}
+
+	return match, match != ""
+}
+
+// editDistance returns the Levenshtein distance between the two strings.
+func editDistance(a, b string) int {
+	var prev = make([]int, len(b)+1)
+	var cur = make([]int, len(b)+1)
+	for j := range prev {
+		prev[j] = j
+	}
+
+	for i := 1; i <= len(a); i++ {
+		cur[0] = i
+		for j := 1; j <= len(b); j++ {
+			var cost = 1
+			if a[i-1] == b[j-1] {
+				cost = 0
+			}
+			cur[j] = min3(prev[j]+1, cur[j-1]+1, prev[j-1]+cost)
+		}
+		prev, cur = cur, prev
+	}
+
+	return prev[len(b)]
+}
+
+func min3(a, b, c int) int {
+	if b < a {
+		a = b
+	}
+	if c < a {
+		a = c
+	}
+	return a
+}
@@ -0,0 +1,68 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package util
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestClosestMatch(t *testing.T) {
+	var candidates = []string{
+		"aws.data.highio.i3", "aws.data.highstorage.d2", "aws.ml.m5",
+	}
+	type args struct {
+		value      string
+		candidates []string
+	}
+	tests := []struct {
+		name  string
+		args  args
+		want  string
+		found bool
+	}{
+		{
+			name:  "finds a typo",
+			args:  args{value: "aws.data.highio.i3x", candidates: candidates},
+			want:  "aws.data.highio.i3",
+			found: true,
+		},
+		{
+			name:  "finds the closest candidate",
+			args:  args{value: "aws.data.highstorage.d3", candidates: candidates},
+			want:  "aws.data.highstorage.d2",
+			found: true,
+		},
+		{
+			name: "doesn't match distant candidates",
+			args: args{value: "gcp.kibana.1", candidates: candidates},
+		},
+		{
+			name: "no candidates",
+			args: args{value: "aws.data.highio.i3"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, found := ClosestMatch(tt.args.value, tt.args.candidates)
+			assert.Equal(t, tt.want, got)
+			assert.Equal(t, tt.found, found)
+		})
+	}
+}
@@ -0,0 +1,64 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package util
+
+import "sort"
+
+// SortTopology sorts the flattened topology elements by their
+// instance_configuration_id, so the order in which the API returns them
+// doesn't produce spurious diffs.
+func SortTopology(topology []interface{}) {
+	sort.SliceStable(topology, func(i, j int) bool {
+		return instanceConfigurationID(topology[i]) < instanceConfigurationID(topology[j])
+	})
+}
+
+// OrderTopologyLike returns the flattened topology elements in the same order
+// as the prior ones, matching them by instance_configuration_id. Elements which
+// aren't part of the prior topology are placed at the end, in their order.
+func OrderTopologyLike(prior, topology []interface{}) []interface{} {
+	var result = make([]interface{}, 0, len(topology))
+	var used = make([]bool, len(topology))
+	for _, p := range prior {
+		id := instanceConfigurationID(p)
+		for i, t := range topology {
+			if !used[i] && instanceConfigurationID(t) == id {
+				result = append(result, t)
+				used[i] = true
+				break
+			}
+		}
+	}
+
+	for i, t := range topology {
+		if !used[i] {
+			result = append(result, t)
+		}
+	}
+
+	return result
+}
+
+func instanceConfigurationID(elem interface{}) string {
+	if m, ok := elem.(map[string]interface{}); ok {
+		if id, ok := m["instance_configuration_id"].(string); ok {
+			return id
+		}
+	}
+	return ""
+}
@@ -0,0 +1,110 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package util
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func newTopologyElem(id string, size string) map[string]interface{} {
+	return map[string]interface{}{
+		"instance_configuration_id": id,
+		"memory_per_node":           size,
+	}
+}
+
+func TestSortTopology(t *testing.T) {
+	topology := []interface{}{
+		newTopologyElem("aws.ml.m5", "1g"),
+		newTopologyElem("aws.data.highio.i3", "8g"),
+		newTopologyElem("aws.master.r5d", "1g"),
+	}
+
+	SortTopology(topology)
+	assert.Equal(t, []interface{}{
+		newTopologyElem("aws.data.highio.i3", "8g"),
+		newTopologyElem("aws.master.r5d", "1g"),
+		newTopologyElem("aws.ml.m5", "1g"),
+	}, topology)
+}
+
+func TestOrderTopologyLike(t *testing.T) {
+	type args struct {
+		prior    []interface{}
+		topology []interface{}
+	}
+	tests := []struct {
+		name string
+		args args
+		want []interface{}
+	}{
+		{
+			name: "returns the topology as is without a prior topology",
+			args: args{topology: []interface{}{
+				newTopologyElem("aws.data.highio.i3", "8g"),
+				newTopologyElem("aws.ml.m5", "1g"),
+			}},
+			want: []interface{}{
+				newTopologyElem("aws.data.highio.i3", "8g"),
+				newTopologyElem("aws.ml.m5", "1g"),
+			},
+		},
+		{
+			name: "orders the topology like the prior one",
+			args: args{
+				prior: []interface{}{
+					newTopologyElem("aws.ml.m5", "1g"),
+					newTopologyElem("aws.data.highio.i3", "4g"),
+				},
+				topology: []interface{}{
+					newTopologyElem("aws.data.highio.i3", "8g"),
+					newTopologyElem("aws.ml.m5", "1g"),
+				},
+			},
+			want: []interface{}{
+				newTopologyElem("aws.ml.m5", "1g"),
+				newTopologyElem("aws.data.highio.i3", "8g"),
+			},
+		},
+		{
+			name: "places the new topology elements at the end",
+			args: args{
+				prior: []interface{}{
+					newTopologyElem("aws.ml.m5", "1g"),
+					newTopologyElem("aws.master.r5d", "1g"),
+				},
+				topology: []interface{}{
+					newTopologyElem("aws.data.highio.i3", "8g"),
+					newTopologyElem("aws.ml.m5", "1g"),
+				},
+			},
+			want: []interface{}{
+				newTopologyElem("aws.ml.m5", "1g"),
+				newTopologyElem("aws.data.highio.i3", "8g"),
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := OrderTopologyLike(tt.args.prior, tt.args.topology)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
@@ -0,0 +1,71 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package util
+
+import (
+	"sync"
+
+	"github.com/elastic/cloud-sdk-go/pkg/api/deploymentapi/trafficfilterapi"
+	"github.com/elastic/cloud-sdk-go/pkg/models"
+)
+
+type trafficFilterCache struct {
+	sync.Mutex
+	entries map[string]*models.TrafficFilterRulesetInfo
+}
+
+// GetTrafficFilter returns the traffic filter ruleset including its
+// associations. Rulesets are memoized for the duration of the plan or apply,
+// so deployments and associations sharing the same ruleset don't obtain it
+// more than once. Any call modifying a ruleset or its associations, including
+// creating, updating or shutting down a deployment with rulesets, must be
+// followed by ForgetTrafficFilter.
+func (m *ProviderMeta) GetTrafficFilter(id string) (*models.TrafficFilterRulesetInfo, error) {
+	var cache = &m.trafficFilters
+
+	cache.Lock()
+	res, ok := cache.entries[id]
+	cache.Unlock()
+	if ok {
+		return res, nil
+	}
+
+	res, err := trafficfilterapi.Get(trafficfilterapi.GetParams{
+		API: m.API, ID: id, IncludeAssociations: true,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	cache.Lock()
+	if cache.entries == nil {
+		cache.entries = make(map[string]*models.TrafficFilterRulesetInfo)
+	}
+	cache.entries[id] = res
+	cache.Unlock()
+
+	return res, nil
+}
+
+// ForgetTrafficFilter removes the traffic filter ruleset from the memoized
+// rulesets, so it's obtained from the API on the next GetTrafficFilter call.
+func (m *ProviderMeta) ForgetTrafficFilter(id string) {
+	m.trafficFilters.Lock()
+	delete(m.trafficFilters.entries, id)
+	m.trafficFilters.Unlock()
+}
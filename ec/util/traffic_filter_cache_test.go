@@ -0,0 +1,55 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package util
+
+import (
+	"testing"
+
+	"github.com/elastic/cloud-sdk-go/pkg/api"
+	"github.com/elastic/cloud-sdk-go/pkg/api/mock"
+	"github.com/elastic/cloud-sdk-go/pkg/models"
+	"github.com/elastic/cloud-sdk-go/pkg/util/ec"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGetTrafficFilter(t *testing.T) {
+	var ruleset = models.TrafficFilterRulesetInfo{
+		ID:   ec.String("some-id"),
+		Name: ec.String("my ruleset"),
+	}
+	var updated = models.TrafficFilterRulesetInfo{
+		ID:   ec.String("some-id"),
+		Name: ec.String("my updated ruleset"),
+	}
+	meta := NewProviderMeta(api.NewMock(
+		mock.New200StructResponse(ruleset),
+		mock.New200StructResponse(updated),
+	), "")
+
+	for i := 0; i < 3; i++ {
+		res, err := meta.GetTrafficFilter("some-id")
+		assert.NoError(t, err)
+		assert.Equal(t, &ruleset, res)
+	}
+
+	meta.ForgetTrafficFilter("some-id")
+
+	res, err := meta.GetTrafficFilter("some-id")
+	assert.NoError(t, err)
+	assert.Equal(t, &updated, res)
+}
@@ -0,0 +1,83 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package util
+
+import (
+	"fmt"
+	"regexp"
+
+	"github.com/blang/semver/v4"
+)
+
+var (
+	regionRegexp     = regexp.MustCompile(`^[a-z0-9]+(-[a-z0-9]+)+$`)
+	templateIDRegexp = regexp.MustCompile(`^[a-zA-Z0-9][a-zA-Z0-9._-]*$`)
+	refIDRegexp      = regexp.MustCompile(`^[a-zA-Z0-9][a-zA-Z0-9_-]*$`)
+)
+
+// ValidateRegion validates that the value is a well formed region, matching
+// both the ESS ("gcp-us-central1", "us-east-1") and ECE ("ece-region") styles.
+func ValidateRegion(i interface{}, k string) ([]string, []error) {
+	return validateRegexp(i, k, regionRegexp,
+		"must be formed by lowercase alphanumeric words separated by hyphens (e.g. gcp-us-central1 or ece-region)",
+	)
+}
+
+// ValidateDeploymentTemplateID validates that the value is a well formed
+// deployment template identifier.
+func ValidateDeploymentTemplateID(i interface{}, k string) ([]string, []error) {
+	return validateRegexp(i, k, templateIDRegexp,
+		"must be formed by alphanumeric characters, dots, underscores or hyphens (e.g. aws-io-optimized)",
+	)
+}
+
+// ValidateRefID validates that the value is a well formed resource ref_id.
+func ValidateRefID(i interface{}, k string) ([]string, []error) {
+	return validateRegexp(i, k, refIDRegexp,
+		"must be formed by alphanumeric characters, underscores or hyphens (e.g. main-elasticsearch)",
+	)
+}
+
+// ValidateVersion validates that the value is a semantic version.
+func ValidateVersion(i interface{}, k string) ([]string, []error) {
+	v, ok := i.(string)
+	if !ok {
+		return nil, []error{fmt.Errorf("expected type of %s to be string", k)}
+	}
+
+	if _, err := semver.Parse(v); err != nil {
+		return nil, []error{fmt.Errorf(
+			`expected %s to be a semantic version (e.g. 7.9.0), got "%s": %w`, k, v, err,
+		)}
+	}
+
+	return nil, nil
+}
+
+func validateRegexp(i interface{}, k string, r *regexp.Regexp, msg string) ([]string, []error) {
+	v, ok := i.(string)
+	if !ok {
+		return nil, []error{fmt.Errorf("expected type of %s to be string", k)}
+	}
+
+	if !r.MatchString(v) {
+		return nil, []error{fmt.Errorf(`invalid %s "%s": %s`, k, v, msg)}
+	}
+
+	return nil, nil
+}
@@ -0,0 +1,70 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package util
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestValidators(t *testing.T) {
+	tests := []struct {
+		name    string
+		fn      func(interface{}, string) ([]string, []error)
+		valid   []interface{}
+		invalid []interface{}
+	}{
+		{
+			name:    "ValidateRegion",
+			fn:      ValidateRegion,
+			valid:   []interface{}{"us-east-1", "gcp-us-central1", "azure-eastus2", "ece-region"},
+			invalid: []interface{}{"", "us_east_1", "US-EAST-1", "useast1", "gcp-us-central1 ", 1},
+		},
+		{
+			name:    "ValidateDeploymentTemplateID",
+			fn:      ValidateDeploymentTemplateID,
+			valid:   []interface{}{"aws-io-optimized", "aws-io-optimized-v2", "default", "gcp.hot_warm"},
+			invalid: []interface{}{"", "-aws", "aws io optimized", "aws/io", 1},
+		},
+		{
+			name:    "ValidateRefID",
+			fn:      ValidateRefID,
+			valid:   []interface{}{"main-elasticsearch", "main-enterprises_search", "kibana2"},
+			invalid: []interface{}{"", "main elasticsearch", "-main", "main.kibana", 1},
+		},
+		{
+			name:    "ValidateVersion",
+			fn:      ValidateVersion,
+			valid:   []interface{}{"7.9.0", "7.10.0-SNAPSHOT", "6.8.12"},
+			invalid: []interface{}{"", "7.9", "v7.9.0", "latest", 7},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			for _, v := range tt.valid {
+				_, errs := tt.fn(v, "key")
+				assert.Empty(t, errs, "expected %v to be valid", v)
+			}
+			for _, v := range tt.invalid {
+				_, errs := tt.fn(v, "key")
+				assert.Len(t, errs, 1, "expected %v to be invalid", v)
+			}
+		})
+	}
+}
@@ -3,7 +3,10 @@ module github.com/elastic/terraform-provider-ec
 go 1.13
 
 require (
+	github.com/blang/semver/v4 v4.0.0
 	github.com/elastic/cloud-sdk-go v1.0.1-0.20200902064126-92c42269d152
+	github.com/go-openapi/runtime v0.19.21
+	github.com/go-openapi/strfmt v0.19.5
 	github.com/hashicorp/terraform-plugin-sdk/v2 v2.0.3
 	github.com/stretchr/testify v1.6.1
 )